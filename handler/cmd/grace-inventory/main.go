@@ -0,0 +1,212 @@
+// Command grace-inventory runs the inventory outside of Lambda, saving the report
+// to the S3 bucket, a local directory or stdout. Settings are read from the same
+// environment variables as the Lambda function, flags take precedence over them
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/GSA/grace-inventory/handler/inv"
+	"github.com/GSA/grace-inventory/handler/spreadsheet"
+)
+
+const usage = `usage: grace-inventory <command> [flags]
+
+commands:
+  run              collect the inventory and save the report
+  list-sheets      print the names of the sheets that can be added to the report
+  validate-config  check the configuration without calling AWS
+
+Run 'grace-inventory <command> -h' for the flags of a command.
+`
+
+// list ... a flag.Value holding a comma delimited list
+type list struct {
+	values *[]string
+}
+
+func (l list) String() string {
+	if l.values == nil {
+		return ""
+	}
+	return strings.Join(*l.values, ",")
+}
+
+func (l list) Set(v string) error {
+	*l.values = nil
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*l.values = append(*l.values, s)
+		}
+	}
+	return nil
+}
+
+// configFlags ... defines a flag for every setting of 'cfg', defaulting to its current value
+func configFlags(fs *flag.FlagSet, cfg *inv.Config) {
+	fs.StringVar(&cfg.Destination, "destination", cfg.Destination, "where the report is saved, s3, local or stdout, defaults to local if -output-dir is set, otherwise s3 (env: destination)")
+	fs.StringVar(&cfg.OutputDir, "output-dir", cfg.OutputDir, "directory the report is saved to by the local destination (env: output_dir)")
+	fs.StringVar(&cfg.BucketID, "bucket", cfg.BucketID, "S3 bucket the report is saved to (env: s3_bucket)")
+	fs.StringVar(&cfg.KmsKeyID, "kms-key-id", cfg.KmsKeyID, "KMS key used to encrypt the report in the S3 bucket (env: kms_key_id)")
+	fs.Var(list{&cfg.Regions}, "regions", "comma delimited list of regions to inventory, the first is the default region, or all/enabled to discover them (env: regions)")
+	fs.Var(list{&cfg.RegionInclude}, "region-include", "comma delimited list of region patterns to inventory (env: region_include)")
+	fs.Var(list{&cfg.RegionExclude}, "region-exclude", "comma delimited list of region patterns to skip (env: region_exclude)")
+	fs.StringVar(&cfg.AccountsInfo, "accounts-info", cfg.AccountsInfo, "accounts to inventory, see the README (env: accounts_info)")
+	fs.StringVar(&cfg.MasterAccountID, "master-account-id", cfg.MasterAccountID, "account ID of the master payer account (env: master_account_id)")
+	fs.Var(list{&cfg.OrgUnits}, "organizational-units", "comma delimited list of organizational units to query for accounts (env: organizational_units)")
+	fs.BoolVar(&cfg.RecursiveOUs, "organizational-units-recursive", cfg.RecursiveOUs, "also query the child organizational units of -organizational-units (env: organizational_units_recursive)")
+	fs.Var(list{&cfg.ExcludeOUs}, "organizational-units-exclude", "comma delimited list of child organizational unit ID, name or path patterns to skip (env: organizational_units_exclude)")
+	fs.Var(list{&cfg.AccountInclude}, "account-include", "comma delimited list of id:, name:, status: or tag: selectors of the accounts to inventory (env: account_include)")
+	fs.Var(list{&cfg.AccountExclude}, "account-exclude", "comma delimited list of id:, name:, status: or tag: selectors of the accounts to skip (env: account_exclude)")
+	fs.StringVar(&cfg.MasterRoleName, "master-role-name", cfg.MasterRoleName, "role assumed in the master payer account (env: master_role_name)")
+	fs.StringVar(&cfg.TenantRoleName, "tenant-role-name", cfg.TenantRoleName, "role assumed in tenant accounts (env: tenant_role_name)")
+	fs.Var(list{&cfg.RoleChain}, "role-chain", "comma delimited list of role ARNs assumed in order before the master and tenant roles (env: role_chain)")
+	fs.StringVar(&cfg.AccountRoles, "account-roles", cfg.AccountRoles, "JSON object of account IDs to role settings (env: account_roles)")
+	fs.Var(list{&cfg.Sheets}, "sheets", "comma delimited list of sheets to add to the report (env: sheets)")
+	fs.IntVar(&cfg.MaxWorkers, "max-workers", cfg.MaxWorkers, "maximum number of queries to run concurrently (env: max_workers)")
+	fs.Var(list{&cfg.RateLimits}, "rate-limits", "comma delimited list of service=rate pairs (env: rate_limits)")
+	fs.IntVar(&cfg.RetryAttempts, "retry-attempts", cfg.RetryAttempts, "attempts made for a throttled request (env: retry_attempts)")
+	fs.BoolVar(&cfg.PartialResults, "partial-results", cfg.PartialResults, "record unexpected errors instead of stopping the report (env: partial_results)")
+	fs.DurationVar(&cfg.DeadlineMargin, "deadline-margin", cfg.DeadlineMargin, "time before the timeout to stop starting queries (env: deadline_margin)")
+	fs.Var(list{&cfg.OutputFormats}, "output-formats", "comma delimited list of report formats (env: output_formats)")
+	fs.BoolVar(&cfg.DriftReport, "drift-report", cfg.DriftReport, "compare the report against the previous NDJSON report (env: drift_report)")
+	fs.StringVar(&cfg.OutputName, "output-name", cfg.OutputName, "name of the report, followed by the time it was created (env: output_name)")
+	fs.StringVar(&cfg.Profile, "profile", cfg.Profile, "shared config profile used for credentials (env: profile)")
+	fs.StringVar(&cfg.Endpoint, "endpoint", cfg.Endpoint, "URL every AWS request is sent to, e.g. a mocked endpoint (env: endpoint)")
+}
+
+// parse ... returns the *inv.Config read from the environment, overridden by 'args'
+func parse(fs *flag.FlagSet, args []string) (*inv.Config, error) {
+	cfg, err := inv.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	configFlags(fs, cfg)
+	err = fs.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", 0, "stop collecting and save a truncated report after this long, 0 for no timeout")
+	cfg, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	inventory, err := inv.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	s := spreadsheet.New(cfg.Filename(time.Now()))
+	for _, sheet := range cfg.SheetNames() {
+		err = s.AddSheet(sheet)
+		if err != nil {
+			return err
+		}
+	}
+	summary, err := inventory.Run(ctx, s)
+	if err != nil {
+		return err
+	}
+	// keep stdout for the report itself
+	if cfg.DestinationType() == inv.DestinationStdout {
+		stdout = os.Stderr
+	}
+	_, err = fmt.Fprintf(stdout, "Report Complete: %s\n", summary)
+	return err
+}
+
+func listSheets(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("list-sheets", flag.ContinueOnError)
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	defaults := make(map[string]bool)
+	for _, name := range inv.DefaultSheets {
+		defaults[name] = true
+	}
+	for _, name := range spreadsheet.Registered() {
+		if defaults[name] {
+			name += " (default)"
+		}
+		_, err = fmt.Fprintln(stdout, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateConfig(args []string, stdout io.Writer) error {
+	cfg, err := parse(flag.NewFlagSet("validate-config", flag.ContinueOnError), args)
+	if err != nil {
+		return err
+	}
+	err = cfg.Validate()
+	if err != nil {
+		return err
+	}
+	var destination string
+	switch cfg.DestinationType() {
+	case inv.DestinationS3:
+		destination = "s3://" + cfg.BucketID
+	case inv.DestinationLocal:
+		destination = cfg.OutputDir
+	default:
+		destination = cfg.DestinationType()
+	}
+	_, err = fmt.Fprintf(stdout, "configuration is valid, saving %s to %s\n", strings.Join(cfg.SheetNames(), ","), destination)
+	return err
+}
+
+// errUsage ... returned by dispatch when the command is missing or unknown
+var errUsage = errors.New("a command is required")
+
+// dispatch ... runs the command named by the first argument
+func dispatch(ctx context.Context, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "run":
+		return run(ctx, args[1:], stdout)
+	case "list-sheets":
+		return listSheets(args[1:], stdout)
+	case "validate-config":
+		return validateConfig(args[1:], stdout)
+	case "help", "-h", "-help", "--help":
+		_, err := fmt.Fprint(stdout, usage)
+		return err
+	}
+	return fmt.Errorf("%w, unknown command %q", errUsage, args[0])
+}
+
+func main() {
+	err := dispatch(context.Background(), os.Args[1:], os.Stdout)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "grace-inventory: %v\n", err)
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(os.Stderr, "\n"+usage)
+		}
+		os.Exit(1)
+	}
+}
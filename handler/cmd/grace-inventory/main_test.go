@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestDispatch(t *testing.T) {
+	tt := map[string]struct {
+		args        []string
+		expected    string
+		expectedErr string
+	}{
+		"no command":      {expectedErr: "a command is required"},
+		"unknown command": {args: []string{"nope"}, expectedErr: `unknown command "nope"`},
+		"help":            {args: []string{"help"}, expected: "usage: grace-inventory"},
+		"list-sheets":     {args: []string{"list-sheets"}, expected: "Accounts (default)\n"},
+		"valid config": {
+			args:     []string{"validate-config", "-output-dir", "out", "-regions", "us-east-1", "-sheets", "Buckets"},
+			expected: "configuration is valid, saving Accounts,Buckets,Errors to out\n",
+		},
+		"stdout": {
+			args:     []string{"validate-config", "-destination", "stdout", "-regions", "us-east-1", "-output-formats", "ndjson"},
+			expected: "to stdout\n",
+		},
+		"invalid config": {
+			args:        []string{"validate-config", "-output-dir", "out", "-regions", "us-east-1", "-output-formats", "pdf"},
+			expectedErr: `unsupported output format "pdf"`,
+		},
+		"unknown flag": {args: []string{"run", "-nope"}, expectedErr: "flag provided but not defined: -nope"},
+	}
+	// settings are read from the environment before the flags
+	for _, k := range []string{"s3_bucket", "kms_key_id", "destination", "output_dir", "regions", "sheets", "output_formats"} {
+		if v, ok := os.LookupEnv(k); ok {
+			defer os.Setenv(k, v)
+			os.Unsetenv(k)
+		}
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := dispatch(context.Background(), tc.args, &buf)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, strings.Contains(buf.String(), tc.expected), buf.String())
+		})
+	}
+}
@@ -1,42 +1,32 @@
 package accounts
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"log"
-	"math"
-	"net/url"
+	"path"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 )
 
-type accountsList struct {
-	Accounts []struct {
-		Status          string
-		Name            string
-		Email           string
-		JoinedMethod    string
-		JoinedTimestamp float64
-		ID              string
-		Arn             string
-	}
-}
-
 // Options ... Options for Accounts() function
 type Options struct {
 	AccountsInfo    string
@@ -45,69 +35,162 @@ type Options struct {
 	MasterRoleName  string
 	TenantRoleName  string
 	OrgUnits        []string
+	// Recursive descends through the child organizational units of OrgUnits
+	Recursive bool
+	// ExcludeOUs are glob patterns matched against the ID, name and path of child
+	// organizational units, matching units and their children are skipped
+	ExcludeOUs []string
+	// Filter selects the accounts returned by AccountsList, see ParseFilter
+	Filter *Filter
+	// Cred returns the credentials used to read the alias of an account, e.g. the Cred of a
+	// credmgr.CredMgr, so the alias is read with the role the account is queried with. If it
+	// is not set, TenantRoleName is assumed in every account but MgmtAccountID
+	Cred func(accountID string) (*credentials.Credentials, error)
+}
+
+// Account ... an organization account and the path of the organizational unit it was found in
+type Account struct {
+	*organizations.Account
+	OUPath string
+}
+
+// OrganizationalUnit ... an organizational unit, its parent and the path of its parent
+type OrganizationalUnit struct {
+	*organizations.OrganizationalUnit
+	ParentID   string
+	ParentPath string
+}
+
+// Policy ... a service control policy and the roots, organizational units
+// and accounts it is attached to, formatted as "Name (ID)" and joined with commas
+type Policy struct {
+	*organizations.PolicySummary
+	Targets string
+}
+
+// DelegatedAdministrator ... a delegated administrator account and the
+// service principals it administers, joined with commas
+type DelegatedAdministrator struct {
+	*organizations.DelegatedAdministrator
+	ServicePrincipals string
 }
 
 // Global compiled regular expressions
-var rIDList = regexp.MustCompile(`^\d{12}(,\d{12})*$`)
+var (
+	rIDList    = regexp.MustCompile(`^\d{12}(,\d{12})*$`)
+	rAccountID = regexp.MustCompile(`^\d{12}$`)
+	rParent    = regexp.MustCompile(`^(r-[0-9a-z]{4,32}|ou-[0-9a-z]{4,32}-[a-z0-9]{8,32})$`)
+)
+
+// ValidateParents ... returns an error if an organizational unit is not a root (r-xxxx) or OU (ou-xxxx-xxxxxxxx)
+// ID, or an exclude pattern is malformed
+func ValidateParents(orgUnits []string, excludes []string) error {
+	for _, ou := range orgUnits {
+		if !rParent.MatchString(ou) {
+			return fmt.Errorf("invalid organizational unit %q, expected a root (r-xxxx) or OU (ou-xxxx-xxxxxxxx) ID", ou)
+		}
+	}
+	for _, p := range excludes {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid organizational unit exclude pattern %q: %v", p, err)
+		}
+	}
+	return nil
+}
 
 // Svc ... type for holding session/config and AWS services
-// For unit testing, set the iamSvc, organizationsSvc and downloaderSvc to
-// an iface mock svc client
+// For unit testing, set the iamSvc, organizationsSvc, downloaderSvc, ssmSvc
+// and dynamodbSvc to an iface mock svc client
 type Svc struct {
 	cfg              client.ConfigProvider
 	iamSvc           iamiface.IAMAPI
 	organizationsSvc organizationsiface.OrganizationsAPI
 	downloaderSvc    s3manageriface.DownloaderAPI
 	stsSvc           stsiface.STSAPI
+	ssmSvc           ssmiface.SSMAPI
+	dynamodbSvc      dynamodbiface.DynamoDBAPI
+	ouNames          map[string]string
+	ouPaths          map[string]string
+	settings         map[string]Settings
 }
 
 // NewAccountsSvc ... creates new Svc struct
 func NewAccountsSvc(cfg client.ConfigProvider) (as *Svc, err error) {
+	return NewChainedAccountsSvc(cfg, cfg)
+}
+
+// NewChainedAccountsSvc ... creates new Svc struct like NewAccountsSvc, calling Organizations, IAM and
+// STS with the credentials of 'chained', e.g. a credmgr.ChainedSession. Account sources are read
+// with the credentials of 'cfg'
+func NewChainedAccountsSvc(cfg client.ConfigProvider, chained client.ConfigProvider) (as *Svc, err error) {
 	as = &Svc{
-		cfg:           cfg,
+		cfg:           chained,
 		downloaderSvc: s3manager.NewDownloader(cfg),
-		stsSvc:        sts.New(cfg),
+		stsSvc:        sts.New(chained),
+		ssmSvc:        ssm.New(cfg),
+		dynamodbSvc:   dynamodb.New(cfg),
 	}
 	return as, nil
 }
 
+// OUPath ... returns the path of the organizational unit the account was found in, e.g.
+// Root/Workloads/Prod, or an empty string if accounts were not listed by organizational unit
+func (as *Svc) OUPath(accountID string) string {
+	return as.ouPaths[accountID]
+}
+
 // AccountsList ... performs Queries or parses accounts and returns all organization accounts
-func (as *Svc) AccountsList(opt Options) ([]*organizations.Account, error) {
+func (as *Svc) AccountsList(ctx context.Context, opt Options) ([]*organizations.Account, error) {
+	var (
+		list []*organizations.Account
+		err  error
+	)
 	switch str := opt.AccountsInfo; {
 	case str == "":
-		return as.queryAccounts(opt)
+		list, err = as.queryAccounts(ctx, opt)
 	case str == "self":
-		return as.selfAccountInfo(opt)
-	case strings.HasPrefix(strings.ToLower(str), "s3://"):
-		return as.parseAccountsFromJSON(opt.AccountsInfo)
+		list, err = as.selfAccountInfo(ctx, opt)
+	case isSource(str):
+		list, err = as.listAccountsFromSource(ctx, str)
 	case rIDList.MatchString(str):
-		return as.getAccountAliases(opt)
+		list, err = as.getAccountAliases(ctx, opt)
 	default:
 		return nil, errors.New("invalid accounts_info")
 	}
+	if err != nil {
+		return nil, err
+	}
+	return as.filterAccounts(ctx, list, opt)
 }
 
 // queryAccounts ... selects between ListAccounts and ListAccountsForParent
-func (as *Svc) queryAccounts(opt Options) ([]*organizations.Account, error) {
-	if as.organizationsSvc == nil {
-		if opt.MasterAccountID != "" && opt.MasterAccountID != opt.MgmtAccountID {
-			arn := "arn:aws:iam::" + opt.MasterAccountID + ":role/" + opt.MasterRoleName
-			cred := stscreds.NewCredentialsWithClient(as.stsSvc, arn)
-			as.organizationsSvc = organizations.New(as.cfg, &aws.Config{Credentials: cred})
-		} else {
-			as.organizationsSvc = organizations.New(as.cfg)
-		}
-	}
+func (as *Svc) queryAccounts(ctx context.Context, opt Options) ([]*organizations.Account, error) {
+	as.organizationsClient(opt)
 	if len(opt.OrgUnits) > 0 {
-		return as.listAccountsForParents(opt.OrgUnits)
+		return as.listAccountsForParents(ctx, opt)
+	}
+	return as.listAccountsForMaster(ctx)
+}
+
+// organizationsClient ... creates the Organizations client, assuming the master role
+// if the master payer account is not the current account
+func (as *Svc) organizationsClient(opt Options) {
+	if as.organizationsSvc != nil {
+		return
+	}
+	if opt.MasterAccountID != "" && opt.MasterAccountID != opt.MgmtAccountID {
+		arn := "arn:aws:iam::" + opt.MasterAccountID + ":role/" + opt.MasterRoleName
+		cred := stscreds.NewCredentialsWithClient(as.stsSvc, arn)
+		as.organizationsSvc = organizations.New(as.cfg, &aws.Config{Credentials: cred})
+	} else {
+		as.organizationsSvc = organizations.New(as.cfg)
 	}
-	return as.listAccountsForMaster()
 }
 
 // listAccounts ... performs ListAccounts and returns all organization accounts
-func (as *Svc) listAccountsForMaster() ([]*organizations.Account, error) {
+func (as *Svc) listAccountsForMaster(ctx context.Context) ([]*organizations.Account, error) {
 	input := &organizations.ListAccountsInput{}
-	result, err := as.organizationsSvc.ListAccounts(input)
+	result, err := as.organizationsSvc.ListAccountsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +201,7 @@ func (as *Svc) listAccountsForMaster() ([]*organizations.Account, error) {
 	}
 	for token != "" {
 		input.NextToken = &token
-		result, err := as.organizationsSvc.ListAccounts(input)
+		result, err := as.organizationsSvc.ListAccountsWithContext(ctx, input)
 		if err != nil {
 			return nil, err
 		}
@@ -132,99 +215,309 @@ func (as *Svc) listAccountsForMaster() ([]*organizations.Account, error) {
 }
 
 // selfAccountInfo ... returns current account ID and alias
-func (as *Svc) selfAccountInfo(opt Options) ([]*organizations.Account, error) {
+func (as *Svc) selfAccountInfo(ctx context.Context, opt Options) ([]*organizations.Account, error) {
 	opt.AccountsInfo = opt.MgmtAccountID
-	return as.getAccountAliases(opt)
+	return as.getAccountAliases(ctx, opt)
 }
 
-// parseAccountsFromJSON ... parses account info from json S3 object
-func (as *Svc) parseAccountsFromJSON(accountsInfo string) ([]*organizations.Account, error) {
-	u, err := url.Parse(accountsInfo)
-	if err != nil {
-		return nil, err
-	}
-	bucket := u.Host
-	key := u.Path
-
-	buff := &aws.WriteAtBuffer{}
-	//  Download the item from the bucket. If an error occurs, log it and exit. Otherwise, notify the user that the download succeeded.
-	_, err = as.downloaderSvc.Download(buff,
-		&s3.GetObjectInput{
-			Bucket: aws.String(bucket),
-			Key:    aws.String(key),
+// getAccountAliases ... returns an account for every ID of opt.AccountsInfo, named by accountName
+func (as *Svc) getAccountAliases(ctx context.Context, opt Options) ([]*organizations.Account, error) {
+	accountIDs := strings.Split(opt.AccountsInfo, ",")
+	var accounts []*organizations.Account
+	for _, acct := range accountIDs {
+		accounts = append(accounts, &organizations.Account{
+			Id:   aws.String(acct),
+			Name: aws.String(as.accountName(ctx, opt, acct)),
 		})
+	}
+	return accounts, nil
+}
 
+// accountName ... returns the first IAM account alias of the account, its Organizations name if it
+// has no alias, or its ID if neither can be read. Failed calls are logged and fall through
+func (as *Svc) accountName(ctx context.Context, opt Options, accountID string) string {
+	svc, err := as.iamClient(opt, accountID)
 	if err != nil {
-		return nil, err
+		log.Printf("Error getting credentials for %v: %v", accountID, err)
+	} else {
+		result, err := svc.ListAccountAliasesWithContext(ctx, &iam.ListAccountAliasesInput{})
+		if err != nil {
+			log.Printf("Error getting account alias for %v: %v", accountID, err)
+		} else if len(result.AccountAliases) > 0 && aws.StringValue(result.AccountAliases[0]) != "" {
+			return aws.StringValue(result.AccountAliases[0])
+		}
 	}
-
-	var dat accountsList
-
-	err = json.Unmarshal(buff.Bytes(), &dat)
+	as.organizationsClient(opt)
+	account, err := as.organizationsSvc.DescribeAccountWithContext(ctx, &organizations.DescribeAccountInput{AccountId: aws.String(accountID)})
 	if err != nil {
-		return nil, err
+		log.Printf("Error getting organization account name for %v: %v", accountID, err)
+	} else if account.Account != nil && aws.StringValue(account.Account.Name) != "" {
+		return aws.StringValue(account.Account.Name)
+	}
+	return accountID
+}
+
+// iamClient ... returns the IAM client of the account, using the credentials returned by opt.Cred
+// if it is set. Otherwise the default credentials are used for opt.MgmtAccountID, and the role
+// opt.TenantRoleName is assumed for any other account
+func (as *Svc) iamClient(opt Options, accountID string) (iamiface.IAMAPI, error) {
+	if as.iamSvc != nil {
+		return as.iamSvc, nil
 	}
+	if opt.Cred != nil {
+		cred, err := opt.Cred(accountID)
+		if err != nil {
+			return nil, err
+		}
+		return iam.New(as.cfg, &aws.Config{Credentials: cred}), nil
+	}
+	if accountID == opt.MgmtAccountID {
+		return iam.New(as.cfg), nil
+	}
+	arn := "arn:aws:iam::" + accountID + ":role/" + opt.TenantRoleName
+	return iam.New(as.cfg, &aws.Config{Credentials: stscreds.NewCredentialsWithClient(as.stsSvc, arn)}), nil
+}
+
+// listAccountsForParents ... performs ListAccountsForParent for each organizational unit, and for
+// each of their child units if opt.Recursive is set, returning every account once with its OU path
+func (as *Svc) listAccountsForParents(ctx context.Context, opt Options) ([]*organizations.Account, error) {
+	as.ouPaths = make(map[string]string)
 	var accounts []*organizations.Account
-	accts := dat.Accounts
-	for _, acct := range accts {
-		var account organizations.Account
-		account.Status = aws.String(acct.Status)
-		account.Name = aws.String(acct.Name)
-		account.Email = aws.String(acct.Email)
-		account.JoinedMethod = aws.String(acct.JoinedMethod)
-		sec, dec := math.Modf(acct.JoinedTimestamp)
-		t := time.Unix(int64(sec), int64(dec*(1e9)))
-		account.JoinedTimestamp = aws.Time(t)
-		account.Id = aws.String(acct.ID)
-		account.Arn = aws.String(acct.Arn)
-		accounts = append(accounts, &account)
+	for _, ou := range opt.OrgUnits {
+		p, err := as.parentPath(ctx, ou)
+		if err != nil {
+			return accounts, err
+		}
+		accounts, err = as.listAccountsForParent(ctx, opt, ou, p, accounts)
+		if err != nil {
+			return accounts, err
+		}
 	}
 	return accounts, nil
 }
 
-func (as *Svc) getAccountAliases(opt Options) ([]*organizations.Account, error) {
-	accountIDs := strings.Split(opt.AccountsInfo, ",")
-	var accounts []*organizations.Account
-	for _, acct := range accountIDs {
-		svc := as.iamSvc
-		var account organizations.Account
-		account.Id = aws.String(acct)
-		if svc == nil {
-			if acct == opt.MgmtAccountID {
-				svc = iam.New(as.cfg)
-			} else {
-				arn := "arn:aws:iam::" + acct + ":role/" + opt.TenantRoleName
-				fmt.Printf("ARN: %v", arn)
-				cred := stscreds.NewCredentialsWithClient(as.stsSvc, arn)
-				svc = iam.New(as.cfg, &aws.Config{Credentials: cred})
+// listAccountsForParent ... appends the accounts of the parent at 'p' to 'accounts', then
+// descends into its child organizational units that are not excluded if opt.Recursive is set
+func (as *Svc) listAccountsForParent(ctx context.Context, opt Options, parentID string, p string, accounts []*organizations.Account) ([]*organizations.Account, error) {
+	err := as.organizationsSvc.ListAccountsForParentPagesWithContext(ctx,
+		&organizations.ListAccountsForParentInput{ParentId: aws.String(parentID)},
+		func(page *organizations.ListAccountsForParentOutput, lastPage bool) bool {
+			for _, a := range page.Accounts {
+				id := aws.StringValue(a.Id)
+				if _, ok := as.ouPaths[id]; ok {
+					continue
+				}
+				as.ouPaths[id] = p
+				accounts = append(accounts, a)
 			}
+			return !lastPage
+		})
+	if err != nil || !opt.Recursive {
+		return accounts, err
+	}
+	var children []*organizations.OrganizationalUnit
+	err = as.organizationsSvc.ListOrganizationalUnitsForParentPagesWithContext(ctx,
+		&organizations.ListOrganizationalUnitsForParentInput{ParentId: aws.String(parentID)},
+		func(page *organizations.ListOrganizationalUnitsForParentOutput, lastPage bool) bool {
+			children = append(children, page.OrganizationalUnits...)
+			return !lastPage
+		})
+	if err != nil {
+		return accounts, err
+	}
+	for _, ou := range children {
+		id, name := aws.StringValue(ou.Id), aws.StringValue(ou.Name)
+		childPath := p + "/" + name
+		if excluded(opt.ExcludeOUs, id, name, childPath) {
+			log.Printf("skipping excluded organizational unit %s (%s)", childPath, id)
+			continue
 		}
-		result, err := svc.ListAccountAliases(&iam.ListAccountAliasesInput{})
+		accounts, err = as.listAccountsForParent(ctx, opt, id, childPath, accounts)
 		if err != nil {
-			log.Printf("Error getting account alias for %v: %v", acct, err)
-		} else {
-			account.Name = result.AccountAliases[0]
+			return accounts, err
 		}
-		accounts = append(accounts, &account)
 	}
 	return accounts, nil
 }
 
-// AccountsForParents ... performs ListAccountsForParent and returns all accounts
-func (as *Svc) listAccountsForParents(orgUnits []string) ([]*organizations.Account, error) {
-	var accounts []*organizations.Account
-	for _, ou := range orgUnits {
-		input := &organizations.ListAccountsForParentInput{
-			ParentId: aws.String(ou),
+// parentPath ... returns the names of the organizational units from the root down to 'id' joined with slashes
+func (as *Svc) parentPath(ctx context.Context, id string) (string, error) {
+	var names []string
+	for {
+		name, err := as.parentName(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		names = append([]string{name}, names...)
+		if strings.HasPrefix(id, "r-") {
+			return strings.Join(names, "/"), nil
 		}
-		err := as.organizationsSvc.ListAccountsForParentPages(input,
-			func(page *organizations.ListAccountsForParentOutput, lastPage bool) bool {
-				accounts = append(accounts, page.Accounts...)
+		result, err := as.organizationsSvc.ListParentsWithContext(ctx, &organizations.ListParentsInput{ChildId: aws.String(id)})
+		if err != nil {
+			return "", err
+		}
+		if len(result.Parents) == 0 {
+			return strings.Join(names, "/"), nil
+		}
+		id = aws.StringValue(result.Parents[0].Id)
+	}
+}
+
+// parentName ... returns the name of a root or organizational unit
+func (as *Svc) parentName(ctx context.Context, id string) (string, error) {
+	if as.ouNames == nil {
+		as.ouNames = make(map[string]string)
+	}
+	if name, ok := as.ouNames[id]; ok {
+		return name, nil
+	}
+	name := id
+	if strings.HasPrefix(id, "r-") {
+		err := as.organizationsSvc.ListRootsPagesWithContext(ctx, &organizations.ListRootsInput{},
+			func(page *organizations.ListRootsOutput, lastPage bool) bool {
+				for _, r := range page.Roots {
+					as.ouNames[aws.StringValue(r.Id)] = aws.StringValue(r.Name)
+				}
 				return !lastPage
 			})
 		if err != nil {
-			return accounts, err
+			return "", err
+		}
+		if n, ok := as.ouNames[id]; ok {
+			name = n
+		}
+	} else {
+		result, err := as.organizationsSvc.DescribeOrganizationalUnitWithContext(ctx,
+			&organizations.DescribeOrganizationalUnitInput{OrganizationalUnitId: aws.String(id)})
+		if err != nil {
+			return "", err
+		}
+		if result.OrganizationalUnit != nil {
+			name = aws.StringValue(result.OrganizationalUnit.Name)
 		}
 	}
-	return accounts, nil
+	as.ouNames[id] = name
+	return name, nil
+}
+
+// excluded ... returns true if any of the patterns matches the ID, name or path of an organizational unit
+func excluded(patterns []string, values ...string) bool {
+	for _, p := range patterns {
+		for _, v := range values {
+			if ok, _ := path.Match(p, v); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// OrganizationalUnits ... performs ListRoots and ListOrganizationalUnitsForParent,
+// returning every organizational unit of the organization with the path of its parent
+func (as *Svc) OrganizationalUnits(ctx context.Context, opt Options) ([]*OrganizationalUnit, error) {
+	as.organizationsClient(opt)
+	var roots []*organizations.Root
+	err := as.organizationsSvc.ListRootsPagesWithContext(ctx, &organizations.ListRootsInput{},
+		func(page *organizations.ListRootsOutput, lastPage bool) bool {
+			roots = append(roots, page.Roots...)
+			return !lastPage
+		})
+	if err != nil {
+		return nil, err
+	}
+	var units []*OrganizationalUnit
+	for _, r := range roots {
+		units, err = as.listOrganizationalUnits(ctx, aws.StringValue(r.Id), aws.StringValue(r.Name), units)
+		if err != nil {
+			return units, err
+		}
+	}
+	return units, nil
+}
+
+// listOrganizationalUnits ... appends the organizational units below the parent at 'p' to 'units'
+func (as *Svc) listOrganizationalUnits(ctx context.Context, parentID string, p string, units []*OrganizationalUnit) ([]*OrganizationalUnit, error) {
+	var children []*organizations.OrganizationalUnit
+	err := as.organizationsSvc.ListOrganizationalUnitsForParentPagesWithContext(ctx,
+		&organizations.ListOrganizationalUnitsForParentInput{ParentId: aws.String(parentID)},
+		func(page *organizations.ListOrganizationalUnitsForParentOutput, lastPage bool) bool {
+			children = append(children, page.OrganizationalUnits...)
+			return !lastPage
+		})
+	if err != nil {
+		return units, err
+	}
+	for _, ou := range children {
+		units = append(units, &OrganizationalUnit{OrganizationalUnit: ou, ParentID: parentID, ParentPath: p})
+		units, err = as.listOrganizationalUnits(ctx, aws.StringValue(ou.Id), p+"/"+aws.StringValue(ou.Name), units)
+		if err != nil {
+			return units, err
+		}
+	}
+	return units, nil
+}
+
+// ServiceControlPolicies ... performs ListPolicies and ListTargetsForPolicy, returning
+// every service control policy of the organization with the targets it is attached to
+func (as *Svc) ServiceControlPolicies(ctx context.Context, opt Options) ([]*Policy, error) {
+	as.organizationsClient(opt)
+	var summaries []*organizations.PolicySummary
+	err := as.organizationsSvc.ListPoliciesPagesWithContext(ctx,
+		&organizations.ListPoliciesInput{Filter: aws.String(organizations.PolicyTypeServiceControlPolicy)},
+		func(page *organizations.ListPoliciesOutput, lastPage bool) bool {
+			summaries = append(summaries, page.Policies...)
+			return !lastPage
+		})
+	if err != nil {
+		return nil, err
+	}
+	var policies []*Policy
+	for _, ps := range summaries {
+		var targets []string
+		err = as.organizationsSvc.ListTargetsForPolicyPagesWithContext(ctx,
+			&organizations.ListTargetsForPolicyInput{PolicyId: ps.Id},
+			func(page *organizations.ListTargetsForPolicyOutput, lastPage bool) bool {
+				for _, t := range page.Targets {
+					targets = append(targets, fmt.Sprintf("%s (%s)", aws.StringValue(t.Name), aws.StringValue(t.TargetId)))
+				}
+				return !lastPage
+			})
+		if err != nil {
+			return policies, err
+		}
+		policies = append(policies, &Policy{PolicySummary: ps, Targets: strings.Join(targets, ", ")})
+	}
+	return policies, nil
+}
+
+// DelegatedAdministrators ... performs ListDelegatedAdministrators and ListDelegatedServicesForAccount,
+// returning every delegated administrator account with the services it administers
+func (as *Svc) DelegatedAdministrators(ctx context.Context, opt Options) ([]*DelegatedAdministrator, error) {
+	as.organizationsClient(opt)
+	var admins []*organizations.DelegatedAdministrator
+	err := as.organizationsSvc.ListDelegatedAdministratorsPagesWithContext(ctx, &organizations.ListDelegatedAdministratorsInput{},
+		func(page *organizations.ListDelegatedAdministratorsOutput, lastPage bool) bool {
+			admins = append(admins, page.DelegatedAdministrators...)
+			return !lastPage
+		})
+	if err != nil {
+		return nil, err
+	}
+	var results []*DelegatedAdministrator
+	for _, a := range admins {
+		var principals []string
+		err = as.organizationsSvc.ListDelegatedServicesForAccountPagesWithContext(ctx,
+			&organizations.ListDelegatedServicesForAccountInput{AccountId: a.Id},
+			func(page *organizations.ListDelegatedServicesForAccountOutput, lastPage bool) bool {
+				for _, svc := range page.DelegatedServices {
+					principals = append(principals, aws.StringValue(svc.ServicePrincipal))
+				}
+				return !lastPage
+			})
+		if err != nil {
+			return results, err
+		}
+		results = append(results, &DelegatedAdministrator{DelegatedAdministrator: a, ServicePrincipals: strings.Join(principals, ", ")})
+	}
+	return results, nil
 }
@@ -3,6 +3,7 @@
 package accounts
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -37,7 +38,7 @@ func TestAccounts(t *testing.T) {
 	if err != nil {
 		t.Fatal("unexpedted error creating new account service")
 	}
-	accounts, err := svc.AccountsList(options)
+	accounts, err := svc.AccountsList(context.Background(), options)
 	if err != nil {
 		t.Fatalf("Accounts() failed: %v", err)
 	}
@@ -68,7 +69,7 @@ func TestAccountsInvalid(t *testing.T) {
 		t.Fatal("unexpedted error creating new account service")
 	}
 
-	_, err = svc.AccountsList(options)
+	_, err = svc.AccountsList(context.Background(), options)
 	if err == nil {
 		t.Fatalf("expected failure for invalid accounts_info")
 	} else if err.Error() != "invalid accounts_info" {
@@ -100,7 +101,7 @@ func TestAccountsSelf(t *testing.T) {
 		t.Fatal("unexpedted error creating new account service")
 	}
 
-	accounts, err := svc.AccountsList(options)
+	accounts, err := svc.AccountsList(context.Background(), options)
 	if err != nil {
 		t.Fatalf("AccountsList() failed: %v", err)
 	}
@@ -142,7 +143,7 @@ func TestAccountsS3(t *testing.T) {
 		t.Fatal("unexpedted error creating new account service")
 	}
 
-	_, err = svc.AccountsList(options)
+	_, err = svc.AccountsList(context.Background(), options)
 	if err != nil {
 		t.Fatalf("AccountsList(\"s3://\") failed: %v", err)
 	}
@@ -173,7 +174,7 @@ func TestIntegrationAccountsList(t *testing.T) {
 		t.Fatal("unexpedted error creating new account service")
 	}
 
-	accounts, err := svc.AccountsList(options)
+	accounts, err := svc.AccountsList(context.Background(), options)
 	if err != nil {
 		t.Fatalf("AccountsList() failed: %v", err)
 	}
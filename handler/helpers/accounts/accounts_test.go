@@ -2,6 +2,8 @@ package accounts
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +12,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/request"
@@ -21,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"gotest.tools/v3/assert"
@@ -58,9 +62,13 @@ func newStubSession(t *testing.T) *session.Session {
 type mockIamSvc struct {
 	iamiface.IAMAPI
 	Resp iam.ListAccountAliasesOutput
+	Err  error
 }
 
-func (m mockIamSvc) ListAccountAliases(in *iam.ListAccountAliasesInput) (*iam.ListAccountAliasesOutput, error) {
+func (m mockIamSvc) ListAccountAliasesWithContext(ctx aws.Context, in *iam.ListAccountAliasesInput, opts ...request.Option) (*iam.ListAccountAliasesOutput, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
 	return &m.Resp, nil
 }
 
@@ -70,16 +78,26 @@ type mockOrgSvc struct {
 	Resp organizations.ListAccountsOutput
 }
 
-func (m mockOrgSvc) ListAccounts(in *organizations.ListAccountsInput) (*organizations.ListAccountsOutput, error) {
+func (m mockOrgSvc) ListAccountsWithContext(ctx aws.Context, in *organizations.ListAccountsInput, opts ...request.Option) (*organizations.ListAccountsOutput, error) {
 	return &m.Resp, nil
 }
 
+// DescribeAccountWithContext ... returns the account of Resp with the ID requested
+func (m mockOrgSvc) DescribeAccountWithContext(ctx aws.Context, in *organizations.DescribeAccountInput, opts ...request.Option) (*organizations.DescribeAccountOutput, error) {
+	for _, a := range m.Resp.Accounts {
+		if aws.StringValue(a.Id) == aws.StringValue(in.AccountId) {
+			return &organizations.DescribeAccountOutput{Account: a}, nil
+		}
+	}
+	return nil, awserr.New(organizations.ErrCodeAccountNotFoundException, "account not found", nil)
+}
+
 // mockDownloaderSvc ... creates a mock of the AWS S3 Manager Downloader API
 type mockDownloaderSvc struct {
 	s3manageriface.DownloaderAPI
 }
 
-func (m mockDownloaderSvc) Download(w io.WriterAt, in *s3.GetObjectInput, fn ...func(*s3manager.Downloader)) (int64, error) {
+func (m mockDownloaderSvc) DownloadWithContext(ctx aws.Context, w io.WriterAt, in *s3.GetObjectInput, fn ...func(*s3manager.Downloader)) (int64, error) {
 	s := `{
 	  "Accounts": [
 	    {"Id": "111111111111"},
@@ -171,6 +189,47 @@ func TestNewAccountsSvc(t *testing.T) {
 	}
 }
 
+func TestNewChainedAccountsSvc(t *testing.T) {
+	sess := newStubSession(t)
+	chained := sess.Copy(&aws.Config{Credentials: credentials.NewStaticCredentials("chained", "chained", "")})
+	svc, err := NewChainedAccountsSvc(sess, chained)
+	assert.NilError(t, err)
+	assert.Equal(t, chained, svc.cfg)
+	assert.Equal(t, chained.Config.Credentials, svc.stsSvc.(*sts.STS).Config.Credentials)
+	assert.Equal(t, sess.Config.Credentials, svc.ssmSvc.(*ssm.SSM).Config.Credentials)
+}
+
+// func (as *Svc) accountName(ctx context.Context, opt Options, accountID string) string
+func TestAccountName(t *testing.T) {
+	org := mockOrgSvc{Resp: organizations.ListAccountsOutput{
+		Accounts: []*organizations.Account{{Id: aws.String("111111111111"), Name: aws.String("org-name")}},
+	}}
+	tt := map[string]struct {
+		iamSvc    mockIamSvc
+		accountID string
+		expected  string
+	}{
+		"zero aliases":     {accountID: "111111111111", expected: "org-name"},
+		"one alias":        {iamSvc: mockIamSvc{Resp: iam.ListAccountAliasesOutput{AccountAliases: aws.StringSlice([]string{"alias"})}}, accountID: "111111111111", expected: "alias"},
+		"multiple aliases": {iamSvc: mockIamSvc{Resp: iam.ListAccountAliasesOutput{AccountAliases: aws.StringSlice([]string{"first", "second"})}}, accountID: "111111111111", expected: "first"},
+		"alias error":      {iamSvc: mockIamSvc{Err: awserr.New("AccessDenied", "denied", nil)}, accountID: "111111111111", expected: "org-name"},
+		"no alias or name": {accountID: "222222222222", expected: "222222222222"},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			svc := &Svc{iamSvc: tc.iamSvc, organizationsSvc: org}
+			assert.Equal(t, tc.expected, svc.accountName(context.Background(), Options{}, tc.accountID))
+		})
+	}
+
+	// every listed account is named, so none is left without a name
+	svc := &Svc{iamSvc: mockIamSvc{}, organizationsSvc: org}
+	accounts, err := svc.AccountsList(context.Background(), Options{AccountsInfo: "111111111111,222222222222"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"org-name", "222222222222"}, []string{aws.StringValue(accounts[0].Name), aws.StringValue(accounts[1].Name)})
+}
+
 // nolint: gocyclo
 func TestAccountsList(t *testing.T) {
 	t.Run("queryAccounts", func(t *testing.T) {
@@ -183,7 +242,7 @@ func TestAccountsList(t *testing.T) {
 				Accounts: []*organizations.Account{{Id: aws.String("123456789012")}},
 			},
 		}
-		accounts, err := mockSvc.AccountsList(options)
+		accounts, err := mockSvc.AccountsList(context.Background(), options)
 		if err != nil {
 			t.Fatalf("Accounts() failed: %v", err)
 		}
@@ -199,7 +258,7 @@ func TestAccountsList(t *testing.T) {
 		options := Options{
 			AccountsInfo: "invalid",
 		}
-		_, err := mockSvc.AccountsList(options)
+		_, err := mockSvc.AccountsList(context.Background(), options)
 		if err == nil {
 			t.Fatalf("expected failure for invalid accounts_info")
 		} else if err.Error() != "invalid accounts_info" {
@@ -226,7 +285,7 @@ func TestAccountsList(t *testing.T) {
 				AccountAliases: []*string{aws.String("test")},
 			},
 		}
-		accounts, err := mockSvc.AccountsList(options)
+		accounts, err := mockSvc.AccountsList(context.Background(), options)
 		if err != nil {
 			t.Fatalf("Accounts() failed: %v", err)
 		}
@@ -250,7 +309,7 @@ func TestAccountsList(t *testing.T) {
 			AccountsInfo:  uri,
 			MgmtAccountID: "123456789012",
 		}
-		_, err := mockSvc.AccountsList(options)
+		_, err := mockSvc.AccountsList(context.Background(), options)
 		if err != nil {
 			t.Fatalf("Accounts(\"s3://\") failed: %v", err)
 		}
@@ -261,7 +320,7 @@ func TestAccountsList(t *testing.T) {
 		options := Options{
 			AccountsInfo: accountsInfo,
 		}
-		accounts, err := mockSvc.AccountsList(options)
+		accounts, err := mockSvc.AccountsList(context.Background(), options)
 		if err != nil {
 			t.Fatalf("Accounts() failed: %v", err)
 		}
@@ -313,7 +372,7 @@ func TestQueryAccounts(t *testing.T) {
 				t.Fatal(err)
 			}
 			svc.stsSvc = mockStsSvc{}
-			actual, err := svc.queryAccounts(tc.opt)
+			actual, err := svc.queryAccounts(context.Background(), tc.opt)
 			if tc.expectedErr == "" {
 				assert.NilError(t, err)
 			} else {
@@ -323,3 +382,254 @@ func TestQueryAccounts(t *testing.T) {
 		})
 	}
 }
+
+// mockOrgTreeSvc ... creates a mock of the AWS Organizations service holding a tree of organizational units
+type mockOrgTreeSvc struct {
+	organizationsiface.OrganizationsAPI
+	names    map[string]string
+	parents  map[string]string
+	accounts map[string][]string
+}
+
+func (m mockOrgTreeSvc) ListAccountsForParentPagesWithContext(ctx aws.Context, in *organizations.ListAccountsForParentInput, fn func(*organizations.ListAccountsForParentOutput, bool) bool, opts ...request.Option) error {
+	page := &organizations.ListAccountsForParentOutput{}
+	for _, id := range m.accounts[aws.StringValue(in.ParentId)] {
+		page.Accounts = append(page.Accounts, &organizations.Account{Id: aws.String(id), Name: aws.String(id)})
+	}
+	fn(page, true)
+	return nil
+}
+
+func (m mockOrgTreeSvc) ListOrganizationalUnitsForParentPagesWithContext(ctx aws.Context, in *organizations.ListOrganizationalUnitsForParentInput, fn func(*organizations.ListOrganizationalUnitsForParentOutput, bool) bool, opts ...request.Option) error {
+	page := &organizations.ListOrganizationalUnitsForParentOutput{}
+	for _, id := range []string{"ou-aaaa-11111111", "ou-aaaa-22222222", "ou-aaaa-33333333"} {
+		if m.parents[id] == aws.StringValue(in.ParentId) {
+			page.OrganizationalUnits = append(page.OrganizationalUnits, &organizations.OrganizationalUnit{Id: aws.String(id), Name: aws.String(m.names[id])})
+		}
+	}
+	fn(page, true)
+	return nil
+}
+
+func (m mockOrgTreeSvc) ListParentsWithContext(ctx aws.Context, in *organizations.ListParentsInput, opts ...request.Option) (*organizations.ListParentsOutput, error) {
+	return &organizations.ListParentsOutput{Parents: []*organizations.Parent{{Id: aws.String(m.parents[aws.StringValue(in.ChildId)])}}}, nil
+}
+
+func (m mockOrgTreeSvc) ListRootsPagesWithContext(ctx aws.Context, in *organizations.ListRootsInput, fn func(*organizations.ListRootsOutput, bool) bool, opts ...request.Option) error {
+	fn(&organizations.ListRootsOutput{Roots: []*organizations.Root{{Id: aws.String("r-abcd"), Name: aws.String(m.names["r-abcd"])}}}, true)
+	return nil
+}
+
+func (m mockOrgTreeSvc) DescribeOrganizationalUnitWithContext(ctx aws.Context, in *organizations.DescribeOrganizationalUnitInput, opts ...request.Option) (*organizations.DescribeOrganizationalUnitOutput, error) {
+	id := aws.StringValue(in.OrganizationalUnitId)
+	return &organizations.DescribeOrganizationalUnitOutput{OrganizationalUnit: &organizations.OrganizationalUnit{Id: aws.String(id), Name: aws.String(m.names[id])}}, nil
+}
+
+func (m mockOrgTreeSvc) ListPoliciesPagesWithContext(ctx aws.Context, in *organizations.ListPoliciesInput, fn func(*organizations.ListPoliciesOutput, bool) bool, opts ...request.Option) error {
+	fn(&organizations.ListPoliciesOutput{Policies: []*organizations.PolicySummary{
+		{Id: aws.String("p-FullAWSAccess"), Name: aws.String("FullAWSAccess"), AwsManaged: aws.Bool(true)},
+		{Id: aws.String("p-12345678"), Name: aws.String("DenyRegions")},
+	}}, true)
+	return nil
+}
+
+func (m mockOrgTreeSvc) ListTargetsForPolicyPagesWithContext(ctx aws.Context, in *organizations.ListTargetsForPolicyInput, fn func(*organizations.ListTargetsForPolicyOutput, bool) bool, opts ...request.Option) error {
+	page := &organizations.ListTargetsForPolicyOutput{}
+	if aws.StringValue(in.PolicyId) == "p-FullAWSAccess" {
+		page.Targets = []*organizations.PolicyTargetSummary{{TargetId: aws.String("r-abcd"), Name: aws.String("Root"), Type: aws.String("ROOT")}}
+	} else {
+		page.Targets = []*organizations.PolicyTargetSummary{
+			{TargetId: aws.String("ou-aaaa-11111111"), Name: aws.String("Workloads"), Type: aws.String("ORGANIZATIONAL_UNIT")},
+			{TargetId: aws.String("444444444444"), Name: aws.String("sandbox"), Type: aws.String("ACCOUNT")},
+		}
+	}
+	fn(page, true)
+	return nil
+}
+
+func (m mockOrgTreeSvc) ListDelegatedAdministratorsPagesWithContext(ctx aws.Context, in *organizations.ListDelegatedAdministratorsInput, fn func(*organizations.ListDelegatedAdministratorsOutput, bool) bool, opts ...request.Option) error {
+	fn(&organizations.ListDelegatedAdministratorsOutput{DelegatedAdministrators: []*organizations.DelegatedAdministrator{
+		{Id: aws.String("222222222222"), Name: aws.String("security")},
+	}}, true)
+	return nil
+}
+
+func (m mockOrgTreeSvc) ListDelegatedServicesForAccountPagesWithContext(ctx aws.Context, in *organizations.ListDelegatedServicesForAccountInput, fn func(*organizations.ListDelegatedServicesForAccountOutput, bool) bool, opts ...request.Option) error {
+	fn(&organizations.ListDelegatedServicesForAccountOutput{DelegatedServices: []*organizations.DelegatedService{
+		{ServicePrincipal: aws.String("guardduty.amazonaws.com")},
+		{ServicePrincipal: aws.String("securityhub.amazonaws.com")},
+	}}, true)
+	return nil
+}
+
+// newMockOrgTree ... returns an organization of Root -> Workloads -> Prod and Root -> Sandbox
+func newMockOrgTree() mockOrgTreeSvc {
+	return mockOrgTreeSvc{
+		names: map[string]string{
+			"r-abcd":           "Root",
+			"ou-aaaa-11111111": "Workloads",
+			"ou-aaaa-22222222": "Prod",
+			"ou-aaaa-33333333": "Sandbox",
+		},
+		parents: map[string]string{
+			"ou-aaaa-11111111": "r-abcd",
+			"ou-aaaa-22222222": "ou-aaaa-11111111",
+			"ou-aaaa-33333333": "r-abcd",
+		},
+		accounts: map[string][]string{
+			"r-abcd":           {"111111111111"},
+			"ou-aaaa-11111111": {"222222222222"},
+			"ou-aaaa-22222222": {"333333333333"},
+			"ou-aaaa-33333333": {"444444444444"},
+		},
+	}
+}
+
+func TestListAccountsForParents(t *testing.T) {
+	tree := newMockOrgTree()
+	tt := map[string]struct {
+		opt      Options
+		expected map[string]string
+	}{
+		"root only": {
+			opt:      Options{OrgUnits: []string{"r-abcd"}},
+			expected: map[string]string{"111111111111": "Root"},
+		},
+		"recursive root": {
+			opt: Options{OrgUnits: []string{"r-abcd"}, Recursive: true},
+			expected: map[string]string{
+				"111111111111": "Root",
+				"222222222222": "Root/Workloads",
+				"333333333333": "Root/Workloads/Prod",
+				"444444444444": "Root/Sandbox",
+			},
+		},
+		"recursive nested ou": {
+			opt: Options{OrgUnits: []string{"ou-aaaa-11111111"}, Recursive: true},
+			expected: map[string]string{
+				"222222222222": "Root/Workloads",
+				"333333333333": "Root/Workloads/Prod",
+			},
+		},
+		"excluded by name and path": {
+			opt: Options{OrgUnits: []string{"r-abcd"}, Recursive: true, ExcludeOUs: []string{"Sand*", "Root/Workloads/Prod"}},
+			expected: map[string]string{
+				"111111111111": "Root",
+				"222222222222": "Root/Workloads",
+			},
+		},
+		"overlapping parents": {
+			opt: Options{OrgUnits: []string{"ou-aaaa-22222222", "r-abcd"}, Recursive: true, ExcludeOUs: []string{"ou-aaaa-33333333"}},
+			expected: map[string]string{
+				"111111111111": "Root",
+				"222222222222": "Root/Workloads",
+				"333333333333": "Root/Workloads/Prod",
+			},
+		},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			svc := &Svc{organizationsSvc: tree}
+			accounts, err := svc.listAccountsForParents(context.Background(), tc.opt)
+			assert.NilError(t, err)
+			actual := make(map[string]string)
+			for _, a := range accounts {
+				actual[aws.StringValue(a.Id)] = svc.OUPath(aws.StringValue(a.Id))
+			}
+			assert.Equal(t, len(tc.expected), len(accounts))
+			assert.DeepEqual(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestValidateParents(t *testing.T) {
+	tt := map[string]struct {
+		orgUnits    []string
+		excludes    []string
+		expectedErr string
+	}{
+		"root and ou": {orgUnits: []string{"r-abcd", "ou-abcd-12345678"}, excludes: []string{"Sandbox*"}},
+		"invalid ou": {
+			orgUnits:    []string{"test_ou"},
+			expectedErr: `invalid organizational unit "test_ou", expected a root (r-xxxx) or OU (ou-xxxx-xxxxxxxx) ID`,
+		},
+		"invalid pattern": {
+			excludes:    []string{"["},
+			expectedErr: `invalid organizational unit exclude pattern "[": syntax error in pattern`,
+		},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := ValidateParents(tc.orgUnits, tc.excludes)
+			if tc.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOrganizationalUnits(t *testing.T) {
+	svc := &Svc{organizationsSvc: newMockOrgTree()}
+	units, err := svc.OrganizationalUnits(context.Background(), Options{})
+	assert.NilError(t, err)
+	var actual [][]string
+	for _, ou := range units {
+		actual = append(actual, []string{aws.StringValue(ou.Id), aws.StringValue(ou.Name), ou.ParentID, ou.ParentPath})
+	}
+	expected := [][]string{
+		{"ou-aaaa-11111111", "Workloads", "r-abcd", "Root"},
+		{"ou-aaaa-22222222", "Prod", "ou-aaaa-11111111", "Root/Workloads"},
+		{"ou-aaaa-33333333", "Sandbox", "r-abcd", "Root"},
+	}
+	assert.DeepEqual(t, expected, actual)
+}
+
+func TestServiceControlPolicies(t *testing.T) {
+	svc := &Svc{organizationsSvc: newMockOrgTree()}
+	policies, err := svc.ServiceControlPolicies(context.Background(), Options{})
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(policies))
+	assert.Equal(t, "Root (r-abcd)", policies[0].Targets)
+	assert.Equal(t, "Workloads (ou-aaaa-11111111), sandbox (444444444444)", policies[1].Targets)
+	assert.Equal(t, "DenyRegions", aws.StringValue(policies[1].Name))
+}
+
+func TestDelegatedAdministrators(t *testing.T) {
+	svc := &Svc{organizationsSvc: newMockOrgTree()}
+	admins, err := svc.DelegatedAdministrators(context.Background(), Options{})
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(admins))
+	assert.Equal(t, "222222222222", aws.StringValue(admins[0].Id))
+	assert.Equal(t, "guardduty.amazonaws.com, securityhub.amazonaws.com", admins[0].ServicePrincipals)
+}
+
+// func (as *Svc) iamClient(opt Options, accountID string) (iamiface.IAMAPI, error)
+func TestIamClient(t *testing.T) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String("us-east-1")})
+	assert.NilError(t, err)
+	svc, err := NewAccountsSvc(sess)
+	assert.NilError(t, err)
+
+	// the credentials of the account are those returned by opt.Cred
+	cred := credentials.NewStaticCredentials("id", "secret", "")
+	var requested []string
+	opt := Options{MgmtAccountID: "111111111111", TenantRoleName: "tenant", Cred: func(accountID string) (*credentials.Credentials, error) {
+		requested = append(requested, accountID)
+		if accountID == "333333333333" {
+			return nil, errors.New("no credentials")
+		}
+		return cred, nil
+	}}
+	client, err := svc.iamClient(opt, "222222222222")
+	assert.NilError(t, err)
+	assert.Equal(t, cred, client.(*iam.IAM).Config.Credentials)
+
+	// the alias is not read when the account has no credentials
+	svc.organizationsSvc = mockOrgSvc{}
+	assert.Equal(t, "333333333333", svc.accountName(context.Background(), opt, "333333333333"))
+	assert.DeepEqual(t, []string{"222222222222", "333333333333"}, requested)
+}
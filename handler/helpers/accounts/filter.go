@@ -0,0 +1,230 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+)
+
+// Filter selector kinds, an include or exclude selector is written as <kind>:<value>
+const (
+	SelectID     = "id"
+	SelectName   = "name"
+	SelectStatus = "status"
+	SelectTag    = "tag"
+)
+
+// statuses ... the account statuses returned by Organizations
+var statuses = map[string]bool{
+	organizations.AccountStatusActive:         true,
+	organizations.AccountStatusSuspended:      true,
+	organizations.AccountStatusPendingClosure: true,
+}
+
+// Filter ... selects the accounts returned by AccountsList, see ParseFilter
+type Filter struct {
+	include selectors
+	exclude selectors
+}
+
+// selectors ... the parsed selectors of one side of a Filter, grouped by kind
+type selectors struct {
+	ids      map[string]bool
+	names    []func(string) bool
+	statuses map[string]bool
+	tags     []tagSelector
+}
+
+// tagSelector ... matches an account tag by key, and by value unless 'value' is empty
+type tagSelector struct {
+	key   string
+	value string
+}
+
+// ParseFilter ... returns a *Filter selecting accounts with 'include' and 'exclude', lists of
+// <kind>:<value> selectors. Kinds are id (an account ID), name (a glob pattern, or a regular
+// expression enclosed in slashes, e.g. name:/^sandbox-\d+$/), status (ACTIVE, SUSPENDED or
+// PENDING_CLOSURE) and tag (key=value, the value is a glob pattern, or key alone for any value).
+// An account is kept if it matches at least one include selector of every kind that is set, and
+// no exclude selector. Returns nil if no selector is set, or an error naming the invalid selector
+func ParseFilter(include []string, exclude []string) (*Filter, error) {
+	f := &Filter{}
+	err := f.include.parse(include)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account include selector %v", err)
+	}
+	err = f.exclude.parse(exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account exclude selector %v", err)
+	}
+	if f.include.empty() && f.exclude.empty() {
+		return nil, nil
+	}
+	return f, nil
+}
+
+// parse ... adds every selector of 'list' to 's', ignoring empty entries
+func (s *selectors) parse(list []string) error {
+	for _, entry := range list {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		kind, value, ok := strings.Cut(entry, ":")
+		if !ok || value == "" {
+			return fmt.Errorf("%q, expected <kind>:<value>", entry)
+		}
+		switch strings.ToLower(kind) {
+		case SelectID:
+			if !rAccountID.MatchString(value) {
+				return fmt.Errorf("%q, expected a 12 digit account ID", entry)
+			}
+			if s.ids == nil {
+				s.ids = make(map[string]bool)
+			}
+			s.ids[value] = true
+		case SelectName:
+			match, err := nameMatcher(value)
+			if err != nil {
+				return fmt.Errorf("%q: %v", entry, err)
+			}
+			s.names = append(s.names, match)
+		case SelectStatus:
+			status := strings.ToUpper(value)
+			if !statuses[status] {
+				return fmt.Errorf("%q, expected ACTIVE, SUSPENDED or PENDING_CLOSURE", entry)
+			}
+			if s.statuses == nil {
+				s.statuses = make(map[string]bool)
+			}
+			s.statuses[status] = true
+		case SelectTag:
+			key, val, _ := strings.Cut(value, "=")
+			if key == "" {
+				return fmt.Errorf("%q, expected tag:key or tag:key=value", entry)
+			}
+			if _, err := path.Match(val, ""); err != nil {
+				return fmt.Errorf("%q: %v", entry, err)
+			}
+			s.tags = append(s.tags, tagSelector{key: key, value: val})
+		default:
+			return fmt.Errorf("%q, expected the kind id, name, status or tag", entry)
+		}
+	}
+	return nil
+}
+
+// nameMatcher ... returns a func matching names with the regular expression enclosed
+// in slashes by 'pattern', or with 'pattern' as a glob pattern
+func nameMatcher(pattern string) (func(string) bool, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		r, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, err
+		}
+		return r.MatchString, nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	return func(name string) bool {
+		ok, _ := path.Match(pattern, name)
+		return ok
+	}, nil
+}
+
+// empty ... returns true if no selector is set
+func (s *selectors) empty() bool {
+	return len(s.ids) == 0 && len(s.names) == 0 && len(s.statuses) == 0 && len(s.tags) == 0
+}
+
+// byTag ... returns true if the filter selects accounts by tag, so their tags must be listed
+func (f *Filter) byTag() bool {
+	return len(f.include.tags) > 0 || len(f.exclude.tags) > 0
+}
+
+// keep ... returns true if the account, with tags 'tags', matches the include selectors of
+// every kind that is set and none of the exclude selectors. Accounts without a status,
+// e.g. read from an ID list, are not selected by status
+func (f *Filter) keep(a *organizations.Account, tags map[string]string) bool {
+	in, ex := &f.include, &f.exclude
+	status := aws.StringValue(a.Status)
+	switch {
+	case len(in.ids) > 0 && !in.ids[aws.StringValue(a.Id)],
+		len(in.names) > 0 && !in.matchName(a),
+		len(in.statuses) > 0 && status != "" && !in.statuses[status],
+		len(in.tags) > 0 && !in.matchTag(tags):
+		return false
+	}
+	return !ex.ids[aws.StringValue(a.Id)] && !ex.matchName(a) && !ex.statuses[status] && !ex.matchTag(tags)
+}
+
+// matchName ... returns true if any name selector matches the name of the account
+func (s *selectors) matchName(a *organizations.Account) bool {
+	for _, match := range s.names {
+		if match(aws.StringValue(a.Name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTag ... returns true if any tag selector matches one of 'tags'
+func (s *selectors) matchTag(tags map[string]string) bool {
+	for _, t := range s.tags {
+		v, ok := tags[t.key]
+		if !ok {
+			continue
+		}
+		if m, _ := path.Match(t.value, v); t.value == "" || m {
+			return true
+		}
+	}
+	return false
+}
+
+// filterAccounts ... returns the accounts of 'list' kept by the Filter of 'opt', performing
+// ListTagsForResource for every account when the filter selects accounts by tag
+func (as *Svc) filterAccounts(ctx context.Context, list []*organizations.Account, opt Options) ([]*organizations.Account, error) {
+	if opt.Filter == nil {
+		return list, nil
+	}
+	if opt.Filter.byTag() {
+		as.organizationsClient(opt)
+	}
+	var kept []*organizations.Account
+	for _, a := range list {
+		var tags map[string]string
+		if opt.Filter.byTag() {
+			var err error
+			tags, err = as.accountTags(ctx, aws.StringValue(a.Id))
+			if err != nil {
+				return nil, fmt.Errorf("failed to list the tags of account %s: %v", aws.StringValue(a.Id), err)
+			}
+		}
+		if opt.Filter.keep(a, tags) {
+			kept = append(kept, a)
+		}
+	}
+	return kept, nil
+}
+
+// accountTags ... performs ListTagsForResource and returns the tags of the account by key
+func (as *Svc) accountTags(ctx context.Context, accountID string) (map[string]string, error) {
+	tags := make(map[string]string)
+	err := as.organizationsSvc.ListTagsForResourcePagesWithContext(ctx, &organizations.ListTagsForResourceInput{ResourceId: aws.String(accountID)},
+		func(page *organizations.ListTagsForResourceOutput, lastPage bool) bool {
+			for _, t := range page.Tags {
+				tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+			}
+			return !lastPage
+		})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
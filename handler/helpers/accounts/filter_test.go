@@ -0,0 +1,105 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"gotest.tools/v3/assert"
+)
+
+// mockTagSvc ... creates a mock of the AWS Organizations service returning the tags of 'tags' by account ID
+type mockTagSvc struct {
+	organizationsiface.OrganizationsAPI
+	tags map[string]map[string]string
+}
+
+func (m mockTagSvc) ListTagsForResourcePagesWithContext(ctx aws.Context, in *organizations.ListTagsForResourceInput, fn func(*organizations.ListTagsForResourceOutput, bool) bool, opts ...request.Option) error {
+	var tags []*organizations.Tag
+	for k, v := range m.tags[aws.StringValue(in.ResourceId)] {
+		tags = append(tags, &organizations.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	fn(&organizations.ListTagsForResourceOutput{Tags: tags}, true)
+	return nil
+}
+
+func TestParseFilter(t *testing.T) {
+	tt := map[string]struct {
+		include     []string
+		exclude     []string
+		expectedErr string
+	}{
+		"empty":   {include: []string{""}},
+		"valid":   {include: []string{"id:111111111111", "name:prod-*", "status:active"}, exclude: []string{"name:/^sandbox-\\d+$/", "tag:breakglass", "tag:env=dev*"}},
+		"no kind": {include: []string{"prod"}, expectedErr: `invalid account include selector "prod", expected <kind>:<value>`},
+		"kind":    {exclude: []string{"ou:Sandbox"}, expectedErr: `invalid account exclude selector "ou:Sandbox", expected the kind id, name, status or tag`},
+		"id":      {include: []string{"id:dev"}, expectedErr: `invalid account include selector "id:dev", expected a 12 digit account ID`},
+		"status":  {include: []string{"status:CLOSED"}, expectedErr: `invalid account include selector "status:CLOSED", expected ACTIVE, SUSPENDED or PENDING_CLOSURE`},
+		"glob":    {exclude: []string{"name:sandbox-["}, expectedErr: `invalid account exclude selector "name:sandbox-["`},
+		"regex":   {exclude: []string{"name:/(/"}, expectedErr: `invalid account exclude selector "name:/(/"`},
+		"tag":     {exclude: []string{"tag:=dev"}, expectedErr: `invalid account exclude selector "tag:=dev", expected tag:key or tag:key=value`},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseFilter(tc.include, tc.exclude)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestFilterAccounts(t *testing.T) {
+	list := []*organizations.Account{
+		{Id: aws.String("111111111111"), Name: aws.String("prod-app"), Status: aws.String("ACTIVE")},
+		{Id: aws.String("222222222222"), Name: aws.String("sandbox-12"), Status: aws.String("ACTIVE")},
+		{Id: aws.String("333333333333"), Name: aws.String("break-glass"), Status: aws.String("ACTIVE")},
+		{Id: aws.String("444444444444"), Name: aws.String("prod-old"), Status: aws.String("SUSPENDED")},
+		{Id: aws.String("555555555555"), Name: aws.String("prod-listed")},
+	}
+	svc := &Svc{organizationsSvc: mockTagSvc{tags: map[string]map[string]string{
+		"111111111111": {"env": "prod"},
+		"222222222222": {"env": "dev"},
+		"333333333333": {"env": "prod", "breakglass": "true"},
+	}}}
+	tt := map[string]struct {
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		"no filter":       {expected: []string{"111111111111", "222222222222", "333333333333", "444444444444", "555555555555"}},
+		"include ids":     {include: []string{"id:111111111111", "id:222222222222"}, expected: []string{"111111111111", "222222222222"}},
+		"exclude id":      {exclude: []string{"id:111111111111"}, expected: []string{"222222222222", "333333333333", "444444444444", "555555555555"}},
+		"include glob":    {include: []string{"name:prod-*"}, expected: []string{"111111111111", "444444444444", "555555555555"}},
+		"exclude regex":   {exclude: []string{"name:/^sandbox-\\d+$/", "name:break*"}, expected: []string{"111111111111", "444444444444", "555555555555"}},
+		"include status":  {include: []string{"status:ACTIVE"}, expected: []string{"111111111111", "222222222222", "333333333333", "555555555555"}},
+		"exclude status":  {exclude: []string{"status:SUSPENDED"}, expected: []string{"111111111111", "222222222222", "333333333333", "555555555555"}},
+		"include tag":     {include: []string{"tag:env=prod"}, expected: []string{"111111111111", "333333333333"}},
+		"exclude tag key": {exclude: []string{"tag:breakglass"}, expected: []string{"111111111111", "222222222222", "444444444444", "555555555555"}},
+		"every kind": {
+			include:  []string{"name:prod-*", "name:break-*", "status:ACTIVE", "tag:env=p*"},
+			exclude:  []string{"tag:breakglass=true"},
+			expected: []string{"111111111111"},
+		},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			f, err := ParseFilter(tc.include, tc.exclude)
+			assert.NilError(t, err)
+			actual, err := svc.filterAccounts(context.Background(), list, Options{Filter: f})
+			assert.NilError(t, err)
+			var ids []string
+			for _, a := range actual {
+				ids = append(ids, aws.StringValue(a.Id))
+			}
+			assert.DeepEqual(t, tc.expected, ids)
+		})
+	}
+}
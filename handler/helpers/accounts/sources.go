@@ -0,0 +1,321 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+)
+
+// Source scheme constants, the prefixes of the accounts_info values read by a Source
+const (
+	SchemeS3       = "s3://"
+	SchemeSSM      = "ssm://"
+	SchemeDynamoDB = "dynamodb://"
+)
+
+// Source ... lists the accounts kept in an account registry, see Svc.Source
+type Source interface {
+	Records(ctx context.Context) ([]*Record, error)
+}
+
+// Record ... an account read from a Source. RoleName, ExternalID, SessionName, DurationSeconds
+// and Regions are optional, when set they replace the settings used to assume the tenant role
+// and the regions inventoried for the account
+type Record struct {
+	Status          string
+	Name            string
+	Email           string
+	JoinedMethod    string
+	JoinedTimestamp float64
+	ID              string `json:"Id" dynamodbav:"Id"`
+	Arn             string
+	RoleName        string
+	ExternalID      string `json:"ExternalId" dynamodbav:"ExternalId"`
+	SessionName     string
+	DurationSeconds int64
+	Regions         []string
+}
+
+// Settings ... the per-account settings of a Record, empty fields use the configured defaults
+type Settings struct {
+	RoleName        string
+	ExternalID      string
+	SessionName     string
+	DurationSeconds int64
+	Regions         []string
+}
+
+// accountsList ... the output of `aws organizations list-accounts`
+type accountsList struct {
+	Accounts []*Record
+}
+
+// isSource ... returns true if 'accountsInfo' is the location of a Source
+func isSource(accountsInfo string) bool {
+	str := strings.ToLower(accountsInfo)
+	return strings.HasPrefix(str, SchemeS3) || strings.HasPrefix(str, SchemeSSM) || strings.HasPrefix(str, SchemeDynamoDB)
+}
+
+// Source ... returns the Source read from 'accountsInfo', a CSV file for s3://bucket/key.csv,
+// the JSON output of `aws organizations list-accounts` for any other s3://bucket/key, an SSM
+// parameter holding the same JSON or a comma delimited list of account IDs for ssm://name
+// and a table with an item per account for dynamodb://table
+func (as *Svc) Source(accountsInfo string) (Source, error) {
+	str := strings.ToLower(accountsInfo)
+	switch {
+	case strings.HasPrefix(str, SchemeS3) && strings.HasSuffix(str, ".csv"):
+		return &csvSource{downloader: as.downloaderSvc, uri: accountsInfo}, nil
+	case strings.HasPrefix(str, SchemeS3):
+		return &jsonSource{downloader: as.downloaderSvc, uri: accountsInfo}, nil
+	case strings.HasPrefix(str, SchemeSSM):
+		return &ssmSource{client: as.ssmSvc, name: accountsInfo[len(SchemeSSM):]}, nil
+	case strings.HasPrefix(str, SchemeDynamoDB):
+		return &dynamoDBSource{client: as.dynamodbSvc, table: accountsInfo[len(SchemeDynamoDB):]}, nil
+	}
+	return nil, fmt.Errorf("unsupported account source %q", accountsInfo)
+}
+
+// Settings ... returns the settings of the account read from a Source, or
+// empty Settings if the accounts were not read from a Source
+func (as *Svc) Settings(accountID string) Settings {
+	return as.settings[accountID]
+}
+
+// listAccountsFromSource ... returns the accounts read from the Source at 'accountsInfo',
+// storing the settings of each account
+func (as *Svc) listAccountsFromSource(ctx context.Context, accountsInfo string) ([]*organizations.Account, error) {
+	src, err := as.Source(accountsInfo)
+	if err != nil {
+		return nil, err
+	}
+	records, err := src.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	as.settings = make(map[string]Settings)
+	var accounts []*organizations.Account
+	for _, r := range records {
+		if !rAccountID.MatchString(r.ID) {
+			return nil, fmt.Errorf("invalid account ID %q in %s", r.ID, accountsInfo)
+		}
+		as.settings[r.ID] = Settings{
+			RoleName:        r.RoleName,
+			ExternalID:      r.ExternalID,
+			SessionName:     r.SessionName,
+			DurationSeconds: r.DurationSeconds,
+			Regions:         r.Regions,
+		}
+		accounts = append(accounts, r.account())
+	}
+	return accounts, nil
+}
+
+// account ... returns the *organizations.Account of the record
+func (r *Record) account() *organizations.Account {
+	var account organizations.Account
+	account.Status = aws.String(r.Status)
+	account.Name = aws.String(r.Name)
+	account.Email = aws.String(r.Email)
+	account.JoinedMethod = aws.String(r.JoinedMethod)
+	if r.JoinedTimestamp != 0 {
+		sec, dec := math.Modf(r.JoinedTimestamp)
+		account.JoinedTimestamp = aws.Time(time.Unix(int64(sec), int64(dec*(1e9))))
+	}
+	account.Id = aws.String(r.ID)
+	account.Arn = aws.String(r.Arn)
+	return &account
+}
+
+// jsonSource ... reads the output of `aws organizations list-accounts` from an S3 object
+type jsonSource struct {
+	downloader s3manageriface.DownloaderAPI
+	uri        string
+}
+
+// Records ... downloads and parses the JSON object
+func (src *jsonSource) Records(ctx context.Context) ([]*Record, error) {
+	b, err := download(ctx, src.downloader, src.uri)
+	if err != nil {
+		return nil, err
+	}
+	return parseJSON(b)
+}
+
+// csvSource ... reads a CSV file from an S3 object, the header row names the Record field
+// of each column, case and underscores are ignored. Regions are separated by spaces or semicolons
+type csvSource struct {
+	downloader s3manageriface.DownloaderAPI
+	uri        string
+}
+
+// Records ... downloads and parses the CSV object
+func (src *csvSource) Records(ctx context.Context) ([]*Record, error) {
+	b, err := download(ctx, src.downloader, src.uri)
+	if err != nil {
+		return nil, err
+	}
+	return parseCSV(bytes.NewReader(b))
+}
+
+// ssmSource ... reads the JSON output of `aws organizations list-accounts`
+// or a comma delimited list of account IDs from an SSM parameter
+type ssmSource struct {
+	client ssmiface.SSMAPI
+	name   string
+}
+
+// Records ... gets and parses the decrypted value of the parameter
+func (src *ssmSource) Records(ctx context.Context) ([]*Record, error) {
+	result, err := src.client.GetParameterWithContext(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(src.name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Parameter == nil {
+		return nil, fmt.Errorf("parameter %s has no value", src.name)
+	}
+	value := strings.TrimSpace(aws.StringValue(result.Parameter.Value))
+	if rIDList.MatchString(value) {
+		var records []*Record
+		for _, id := range strings.Split(value, ",") {
+			records = append(records, &Record{ID: id})
+		}
+		return records, nil
+	}
+	return parseJSON([]byte(value))
+}
+
+// dynamoDBSource ... scans a DynamoDB table holding an item per account, the
+// attributes are named after the Record fields
+type dynamoDBSource struct {
+	client dynamodbiface.DynamoDBAPI
+	table  string
+}
+
+// Records ... scans the table and unmarshals every item
+func (src *dynamoDBSource) Records(ctx context.Context) ([]*Record, error) {
+	var (
+		records []*Record
+		uerr    error
+	)
+	err := src.client.ScanPagesWithContext(ctx, &dynamodb.ScanInput{TableName: aws.String(src.table)},
+		func(page *dynamodb.ScanOutput, lastPage bool) bool {
+			var items []*Record
+			uerr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items)
+			records = append(records, items...)
+			return uerr == nil && !lastPage
+		})
+	if err != nil {
+		return nil, err
+	}
+	if uerr != nil {
+		return nil, fmt.Errorf("failed to read table %s: %v", src.table, uerr)
+	}
+	return records, nil
+}
+
+// download ... returns the content of the S3 object at 'uri'
+func download(ctx context.Context, downloader s3manageriface.DownloaderAPI, uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	buff := &aws.WriteAtBuffer{}
+	_, err = downloader.DownloadWithContext(ctx, buff,
+		&s3.GetObjectInput{
+			Bucket: aws.String(u.Host),
+			Key:    aws.String(u.Path),
+		})
+	if err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+// parseJSON ... parses the output of `aws organizations list-accounts`
+func parseJSON(b []byte) ([]*Record, error) {
+	var dat accountsList
+	err := json.Unmarshal(b, &dat)
+	if err != nil {
+		return nil, err
+	}
+	return dat.Accounts, nil
+}
+
+// parseCSV ... parses a CSV file with a header row naming the Record field of each column
+func parseCSV(r io.Reader) ([]*Record, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "_", ""))] = i
+	}
+	if _, ok := columns["id"]; !ok {
+		return nil, fmt.Errorf("CSV header has no Id column: %s", strings.Join(rows[0], ","))
+	}
+	field := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	var records []*Record
+	for _, row := range rows[1:] {
+		rec := &Record{
+			ID:           field(row, "id"),
+			Name:         field(row, "name"),
+			Status:       field(row, "status"),
+			Email:        field(row, "email"),
+			JoinedMethod: field(row, "joinedmethod"),
+			Arn:          field(row, "arn"),
+			RoleName:     field(row, "rolename"),
+			ExternalID:   field(row, "externalid"),
+			SessionName:  field(row, "sessionname"),
+		}
+		regions := strings.FieldsFunc(field(row, "regions"), func(r rune) bool {
+			return r == ' ' || r == ';'
+		})
+		if len(regions) > 0 {
+			rec.Regions = regions
+		}
+		if d := field(row, "durationseconds"); d != "" {
+			rec.DurationSeconds, err = strconv.ParseInt(d, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid DurationSeconds for account %s: %v", rec.ID, err)
+			}
+		}
+		if ts := field(row, "joinedtimestamp"); ts != "" {
+			rec.JoinedTimestamp, err = strconv.ParseFloat(ts, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JoinedTimestamp for account %s: %v", rec.ID, err)
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
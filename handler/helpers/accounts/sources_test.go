@@ -0,0 +1,132 @@
+package accounts
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"gotest.tools/v3/assert"
+)
+
+// mockObjectSvc ... creates a mock of the AWS S3 Manager Downloader API returning 'body'
+type mockObjectSvc struct {
+	s3manageriface.DownloaderAPI
+	body string
+}
+
+func (m mockObjectSvc) DownloadWithContext(ctx aws.Context, w io.WriterAt, in *s3.GetObjectInput, fn ...func(*s3manager.Downloader)) (int64, error) {
+	n, err := w.WriteAt([]byte(m.body), 0)
+	return int64(n), err
+}
+
+// mockSsmSvc ... creates a mock of the AWS Systems Manager service returning 'value' for every parameter
+type mockSsmSvc struct {
+	ssmiface.SSMAPI
+	value string
+}
+
+func (m mockSsmSvc) GetParameterWithContext(ctx aws.Context, in *ssm.GetParameterInput, opts ...request.Option) (*ssm.GetParameterOutput, error) {
+	return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Name: in.Name, Value: aws.String(m.value)}}, nil
+}
+
+// mockDynamoDBSvc ... creates a mock of the AWS DynamoDB service returning 'items' over two pages
+type mockDynamoDBSvc struct {
+	dynamodbiface.DynamoDBAPI
+	items []map[string]*dynamodb.AttributeValue
+}
+
+func (m mockDynamoDBSvc) ScanPagesWithContext(ctx aws.Context, in *dynamodb.ScanInput, fn func(*dynamodb.ScanOutput, bool) bool, opts ...request.Option) error {
+	if fn(&dynamodb.ScanOutput{Items: m.items[:1]}, false) {
+		fn(&dynamodb.ScanOutput{Items: m.items[1:]}, true)
+	}
+	return nil
+}
+
+func TestListAccountsFromSource(t *testing.T) {
+	svc := &Svc{
+		downloaderSvc: mockObjectSvc{body: `{"Accounts": [
+			{"Id": "111111111111", "Name": "dev", "Status": "ACTIVE", "JoinedTimestamp": 1577934245.5},
+			{"Id": "222222222222", "Name": "prod", "RoleName": "InventoryRole", "ExternalId": "ext-123", "Regions": ["us-east-1"]}
+		]}`},
+		ssmSvc: mockSsmSvc{value: "111111111111,222222222222"},
+		dynamodbSvc: mockDynamoDBSvc{items: []map[string]*dynamodb.AttributeValue{
+			{"Id": {S: aws.String("111111111111")}, "Name": {S: aws.String("dev")}},
+			{
+				"Id":       {S: aws.String("222222222222")},
+				"Name":     {S: aws.String("prod")},
+				"RoleName": {S: aws.String("InventoryRole")},
+				"Regions":  {SS: []*string{aws.String("us-east-1")}},
+			},
+		}},
+	}
+	csvSvc := *svc
+	csvSvc.downloaderSvc = mockObjectSvc{body: "id,name,role_name,external_id,duration_seconds,regions\n" +
+		"111111111111,dev,,,,\n" +
+		"222222222222,prod,InventoryRole,ext-123,3600,us-east-1\n"}
+	tt := map[string]struct {
+		svc          *Svc
+		accountsInfo string
+		names        []string
+		expected     Settings
+	}{
+		"json":     {svc: svc, accountsInfo: "s3://bucket/accounts.json", names: []string{"dev", "prod"}, expected: Settings{RoleName: "InventoryRole", ExternalID: "ext-123", Regions: []string{"us-east-1"}}},
+		"csv":      {svc: &csvSvc, accountsInfo: "s3://bucket/accounts.CSV", names: []string{"dev", "prod"}, expected: Settings{RoleName: "InventoryRole", ExternalID: "ext-123", DurationSeconds: 3600, Regions: []string{"us-east-1"}}},
+		"ssm":      {svc: svc, accountsInfo: "ssm:///grace/accounts", names: []string{"", ""}},
+		"dynamodb": {svc: svc, accountsInfo: "dynamodb://accounts", names: []string{"dev", "prod"}, expected: Settings{RoleName: "InventoryRole", Regions: []string{"us-east-1"}}},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			accounts, err := tc.svc.AccountsList(context.Background(), Options{AccountsInfo: tc.accountsInfo})
+			assert.NilError(t, err)
+			var names []string
+			for _, a := range accounts {
+				names = append(names, aws.StringValue(a.Name))
+			}
+			assert.DeepEqual(t, tc.names, names)
+			assert.Equal(t, "111111111111", aws.StringValue(accounts[0].Id))
+			assert.DeepEqual(t, Settings{}, tc.svc.Settings("111111111111"))
+			assert.DeepEqual(t, tc.expected, tc.svc.Settings("222222222222"))
+		})
+	}
+}
+
+func TestSourceErrors(t *testing.T) {
+	tt := map[string]struct {
+		svc          *Svc
+		accountsInfo string
+		expectedErr  string
+	}{
+		"invalid id": {
+			svc:          &Svc{downloaderSvc: mockObjectSvc{body: `{"Accounts": [{"Id": "dev"}]}`}},
+			accountsInfo: "s3://bucket/accounts.json",
+			expectedErr:  `invalid account ID "dev" in s3://bucket/accounts.json`,
+		},
+		"csv without id": {
+			svc:          &Svc{downloaderSvc: mockObjectSvc{body: "name\ndev\n"}},
+			accountsInfo: "s3://bucket/accounts.csv",
+			expectedErr:  "CSV header has no Id column: name",
+		},
+		"ssm json": {
+			svc:          &Svc{ssmSvc: mockSsmSvc{value: "not json"}},
+			accountsInfo: "ssm://accounts",
+			expectedErr:  "invalid character 'o' in literal null (expecting 'u')",
+		},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.svc.AccountsList(context.Background(), Options{AccountsInfo: tc.accountsInfo})
+			assert.Error(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -2,6 +2,9 @@ package credmgr
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -10,13 +13,107 @@ import (
 	"github.com/aws/aws-sdk-go/service/organizations"
 )
 
-// CredMgr ... stores credentials for each *organizations.Account provided to New
+// CredMgr ... stores credentials for each *organizations.Account provided to New, keyed by account ID
 type CredMgr struct {
 	creds map[string]*credentials.Credentials
 }
 
+// Role ... the settings used to assume the role of an account, empty fields use the
+// tenant role name and the defaults of stscreds.AssumeRoleProvider
+type Role struct {
+	Name        string
+	ExternalID  string
+	SessionName string
+	Duration    time.Duration
+}
+
+// Role setting limits, see the AssumeRole API
+var (
+	rRoleName    = regexp.MustCompile(`^[\w+=,.@-]{1,64}$`)
+	rExternalID  = regexp.MustCompile(`^[\w+=,.@:/-]{2,}$`)
+	rSessionName = regexp.MustCompile(`^[\w+=,.@-]{2,64}$`)
+	rRoleArn     = regexp.MustCompile(`^arn:aws[\w-]*:iam::(\d{12}):role/[\w+=,.@/-]{1,512}$`)
+	minDuration  = 15 * time.Minute
+	maxDuration  = 12 * time.Hour
+)
+
+// Validate ... returns an error if a setting of the role would be rejected by AssumeRole
+func (r Role) Validate() error {
+	if r.Name != "" && !rRoleName.MatchString(r.Name) {
+		return fmt.Errorf("invalid role name %q", r.Name)
+	}
+	if r.ExternalID != "" && (len(r.ExternalID) > 1224 || !rExternalID.MatchString(r.ExternalID)) {
+		return fmt.Errorf("invalid external ID %q", r.ExternalID)
+	}
+	if r.SessionName != "" && !rSessionName.MatchString(r.SessionName) {
+		return fmt.Errorf("invalid session name %q", r.SessionName)
+	}
+	if r.Duration != 0 && (r.Duration < minDuration || r.Duration > maxDuration) {
+		return fmt.Errorf("invalid session duration %s, must be between %s and %s", r.Duration, minDuration, maxDuration)
+	}
+	return nil
+}
+
+// ValidateRoles ... returns an error naming the first account, by ID, with an invalid Role
+func ValidateRoles(roles map[string]Role) error {
+	ids := make([]string, 0, len(roles))
+	for id := range roles {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := roles[id].Validate(); err != nil {
+			return fmt.Errorf("invalid assume role settings for account %s: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// ValidateChain ... returns an error naming the first role ARN of 'chain' that is not valid
+func ValidateChain(chain []string) error {
+	for _, arn := range chain {
+		if !rRoleArn.MatchString(arn) {
+			return fmt.Errorf("invalid role chain ARN %q, expected arn:aws:iam::<account id>:role/<role name>", arn)
+		}
+	}
+	return nil
+}
+
+// ChainedSession ... returns a copy of 'sess' using the credentials of the last role of 'chain',
+// each role is assumed with the credentials of the role before it, the first with those of 'sess'.
+// The credentials of every role are cached, and refreshed with the credentials of the role before
+// it when they expire, so the chain is only assumed again when needed. Returns 'sess' if 'chain' is empty
+func ChainedSession(sess *session.Session, chain []string) *session.Session {
+	for _, arn := range chain {
+		sess = sess.Copy(&aws.Config{Credentials: stscreds.NewCredentials(sess, arn)})
+	}
+	return sess
+}
+
+// ChainAccount ... returns the account ID of the last role of 'chain', or an empty string
+// if 'chain' is empty or the ARN is not valid
+func ChainAccount(chain []string) string {
+	if len(chain) == 0 {
+		return ""
+	}
+	m := rRoleArn.FindStringSubmatch(chain[len(chain)-1])
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
 // New ... returns a *CredMgr after creating new *credential.Credential for all *organization.Account provided
 func New(cfg *session.Session, mgmtAccount string, tenantRoleName string, accounts []*organizations.Account) *CredMgr {
+	return NewWithRoles(cfg, mgmtAccount, tenantRoleName, accounts, nil)
+}
+
+// NewWithRoles ... returns a *CredMgr like New, assuming the Role held in 'roles' for each
+// account ID instead of 'tenantRoleName' with the default settings. The roles should be
+// checked with ValidateRoles first. Roles are assumed with the credentials of 'cfg', pass
+// a ChainedSession to assume them from the last role of a chain, whose account is 'mgmtAccount'
+// (see ChainAccount)
+func NewWithRoles(cfg *session.Session, mgmtAccount string, tenantRoleName string, accounts []*organizations.Account, roles map[string]Role) *CredMgr {
 	c := &CredMgr{creds: make(map[string]*credentials.Credentials)}
 	// prevent nil pointer crash, if session is nil
 	if cfg == nil {
@@ -24,19 +121,36 @@ func New(cfg *session.Session, mgmtAccount string, tenantRoleName string, accoun
 	}
 	for _, a := range accounts {
 		if aws.StringValue(a.Id) == mgmtAccount {
-			c.creds[aws.StringValue(a.Name)] = cfg.Config.Credentials
+			c.creds[aws.StringValue(a.Id)] = cfg.Config.Credentials
 		} else {
-			arn := "arn:aws:iam::" + aws.StringValue(a.Id) + ":role/" + tenantRoleName
-			c.creds[aws.StringValue(a.Name)] = stscreds.NewCredentials(cfg, arn)
+			role := roles[aws.StringValue(a.Id)]
+			if role.Name == "" {
+				role.Name = tenantRoleName
+			}
+			arn := "arn:aws:iam::" + aws.StringValue(a.Id) + ":role/" + role.Name
+			c.creds[aws.StringValue(a.Id)] = stscreds.NewCredentials(cfg, arn, role.options)
 		}
 	}
 	return c
 }
 
-// Cred ... returns the *credential.Credential for the account name provided, if found
-func (mgr *CredMgr) Cred(account string) (*credentials.Credentials, error) {
-	if val, ok := mgr.creds[account]; ok {
+// Cred ... returns the *credential.Credential for the account ID provided, if found
+func (mgr *CredMgr) Cred(accountID string) (*credentials.Credentials, error) {
+	if val, ok := mgr.creds[accountID]; ok {
 		return val, nil
 	}
-	return nil, fmt.Errorf("could not find a credential for %s", account)
+	return nil, fmt.Errorf("could not find a credential for account %s", accountID)
+}
+
+// options ... sets the ExternalID, session name and duration of the role on the provider, when they are set
+func (r Role) options(p *stscreds.AssumeRoleProvider) {
+	if r.ExternalID != "" {
+		p.ExternalID = aws.String(r.ExternalID)
+	}
+	if r.SessionName != "" {
+		p.RoleSessionName = r.SessionName
+	}
+	if r.Duration != 0 {
+		p.Duration = r.Duration
+	}
 }
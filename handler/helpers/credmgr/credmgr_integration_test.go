@@ -28,13 +28,13 @@ func TestIntegrationNew(t *testing.T) {
 		if len(c.creds) == 0 {
 			t.Fatal("accounts expected 1, got 0")
 		}
-		if c.creds[currUser] == nil {
-			t.Fatalf("account '%s' does not exist", currUser)
+		if c.creds[currAcct] == nil {
+			t.Fatalf("account '%s' does not exist", currAcct)
 		}
 	})
 }
 
-// func (mgr *CredMgr) Cred(account string) (*credentials.Credentials, error)
+// func (mgr *CredMgr) Cred(accountID string) (*credentials.Credentials, error)
 func TestIntegrationCred(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
@@ -43,12 +43,16 @@ func TestIntegrationCred(t *testing.T) {
 	currUser := awstest.GetIamCurrentUserName(t)
 	currAcct := awstest.GetAccountId(t)
 	c := New(sess, "", "", []*organizations.Account{{Id: &currAcct, Name: &currUser}})
-	_, err = c.Cred(currUser)
+	_, err = c.Cred(currAcct)
 	if err != nil {
-		t.Fatalf("failed to get cred for user %s", currUser)
+		t.Fatalf("failed to get cred for account %s", currAcct)
+	}
+	_, err = c.Cred(currUser)
+	if err == nil {
+		t.Fatalf("Cred should fail when called with the account name")
 	}
-	_, err = c.Cred("invalid_user")
+	_, err = c.Cred("invalid_account")
 	if err == nil {
-		t.Fatalf("Cred should fail if user isn't in map")
+		t.Fatalf("Cred should fail if account isn't in map")
 	}
 }
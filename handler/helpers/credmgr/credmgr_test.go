@@ -1,11 +1,17 @@
 package credmgr
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/organizations"
 )
@@ -43,13 +49,24 @@ func TestNew(t *testing.T) {
 		if len(c.creds) == 0 {
 			t.Fatal("accounts expected 1, got 0")
 		}
-		if c.creds[currUser] == nil {
-			t.Fatalf("account '%s' does not exist", currUser)
+		if c.creds[currAcct] == nil {
+			t.Fatalf("account '%s' does not exist", currAcct)
+		}
+	})
+	t.Run("duplicate names test", func(t *testing.T) {
+		c := New(sess, "", "", []*organizations.Account{
+			{Id: aws.String("111111111111"), Name: aws.String("same")},
+			{Id: aws.String("222222222222"), Name: aws.String("same")},
+			{Id: aws.String("333333333333")},
+			{Id: aws.String("444444444444")},
+		})
+		if len(c.creds) != 4 {
+			t.Fatalf("accounts expected 4, got %d", len(c.creds))
 		}
 	})
 }
 
-// func (mgr *CredMgr) Cred(account string) (*credentials.Credentials, error)
+// func (mgr *CredMgr) Cred(accountID string) (*credentials.Credentials, error)
 func TestCred(t *testing.T) {
 	sess, err := mockNewSession(&aws.Config{Region: aws.String(defaultRegion)})
 	if err != nil {
@@ -58,12 +75,189 @@ func TestCred(t *testing.T) {
 	currUser := "testUser"
 	currAcct := "testAccount"
 	c := New(sess, "", "", []*organizations.Account{{Id: &currAcct, Name: &currUser}})
-	_, err = c.Cred(currUser)
+	_, err = c.Cred(currAcct)
 	if err != nil {
-		t.Fatalf("failed to get cred for user %s", currUser)
+		t.Fatalf("failed to get cred for account %s", currAcct)
+	}
+	_, err = c.Cred(currUser)
+	if err == nil {
+		t.Fatalf("Cred should fail when called with the account name")
 	}
-	_, err = c.Cred("invalid_user")
+	_, err = c.Cred("invalid_account")
 	if err == nil {
-		t.Fatalf("Cred should fail if user isn't in map")
+		t.Fatalf("Cred should fail if account isn't in map")
+	}
+}
+
+// assumeRoleCall ... the parameters of an AssumeRole request and the access key that signed it
+type assumeRoleCall struct {
+	params url.Values
+	key    string
+}
+
+// newAssumeRoleSession ... returns a session sending requests to a server that records every
+// AssumeRole call and returns credentials whose access key is the ARN of the assumed role
+func newAssumeRoleSession(t *testing.T) (*session.Session, *[]assumeRoleCall) {
+	var calls []assumeRoleCall
+	rKey := regexp.MustCompile(`Credential=(.+)/\d{8}/`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		call := assumeRoleCall{params: r.PostForm}
+		if m := rKey.FindStringSubmatch(r.Header.Get("Authorization")); m != nil {
+			call.key = m[1]
+		}
+		calls = append(calls, call)
+		fmt.Fprintf(w, `<AssumeRoleResponse><AssumeRoleResult><Credentials>
+			<AccessKeyId>%s</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken>
+			<Expiration>%s</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`,
+			r.PostForm.Get("RoleArn"), time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	}))
+	t.Cleanup(server.Close)
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(defaultRegion),
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("test", "test", ""),
+	})
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	return sess, &calls
+}
+
+// NewWithRoles(cfg *session.Session, mgmtAccount string, tenantRoleName string, accounts []*organizations.Account, roles map[string]Role) *CredMgr
+func TestNewWithRoles(t *testing.T) {
+	sess, calls := newAssumeRoleSession(t)
+	c := NewWithRoles(sess, "111111111111", "tenantRole", []*organizations.Account{
+		{Id: aws.String("111111111111")},
+		{Id: aws.String("222222222222")},
+		{Id: aws.String("333333333333")},
+	}, map[string]Role{
+		"111111111111": {Name: "ignored"},
+		"333333333333": {Name: "otherRole", ExternalID: "ext-123", SessionName: "grace-inventory", Duration: time.Hour},
+	})
+	if c.creds["111111111111"] != sess.Config.Credentials {
+		t.Fatal("the management account should use the session credentials")
+	}
+	for _, id := range []string{"222222222222", "333333333333"} {
+		if _, err := c.creds[id].Get(); err != nil {
+			t.Fatalf("failed to get credentials for %s: %v", id, err)
+		}
+	}
+	if len(*calls) != 2 {
+		t.Fatalf("AssumeRole calls expected 2, got %d", len(*calls))
+	}
+	params := []url.Values{(*calls)[0].params, (*calls)[1].params}
+	if arn := params[0].Get("RoleArn"); arn != "arn:aws:iam::222222222222:role/tenantRole" {
+		t.Fatalf("RoleArn invalid, expected the tenant role, got: %s", arn)
+	}
+	if params[0].Get("ExternalId") != "" {
+		t.Fatalf("ExternalId should not be set, got: %s", params[0].Get("ExternalId"))
+	}
+	expected := map[string]string{
+		"RoleArn":         "arn:aws:iam::333333333333:role/otherRole",
+		"ExternalId":      "ext-123",
+		"RoleSessionName": "grace-inventory",
+		"DurationSeconds": "3600",
+	}
+	for k, v := range expected {
+		if params[1].Get(k) != v {
+			t.Fatalf("%s invalid, expected: %s, got: %s", k, v, params[1].Get(k))
+		}
+	}
+}
+
+// func ChainedSession(sess *session.Session, chain []string) *session.Session
+func TestChainedSession(t *testing.T) {
+	sess, calls := newAssumeRoleSession(t)
+	chain := []string{"arn:aws:iam::111111111111:role/hub", "arn:aws:iam::222222222222:role/spoke"}
+	chained := ChainedSession(sess, chain)
+	c := NewWithRoles(chained, ChainAccount(chain), "tenantRole", []*organizations.Account{
+		{Id: aws.String("222222222222")},
+		{Id: aws.String("333333333333")},
+	}, nil)
+	if c.creds["222222222222"] != chained.Config.Credentials {
+		t.Fatal("the account of the last role should use the chained credentials")
+	}
+	creds := c.creds["333333333333"]
+	for i := 0; i < 2; i++ {
+		if _, err := creds.Get(); err != nil {
+			t.Fatalf("failed to get credentials: %v", err)
+		}
+	}
+	// expire the tenant credentials, only the tenant role should be assumed again
+	creds.Expire()
+	if _, err := creds.Get(); err != nil {
+		t.Fatalf("failed to refresh credentials: %v", err)
+	}
+	expected := []assumeRoleCall{
+		{key: "test", params: url.Values{"RoleArn": {chain[0]}}},
+		{key: chain[0], params: url.Values{"RoleArn": {chain[1]}}},
+		{key: chain[1], params: url.Values{"RoleArn": {"arn:aws:iam::333333333333:role/tenantRole"}}},
+		{key: chain[1], params: url.Values{"RoleArn": {"arn:aws:iam::333333333333:role/tenantRole"}}},
+	}
+	if len(*calls) != len(expected) {
+		t.Fatalf("AssumeRole calls expected %d, got %d", len(expected), len(*calls))
+	}
+	for i, e := range expected {
+		actual := (*calls)[i]
+		if actual.key != e.key || actual.params.Get("RoleArn") != e.params.Get("RoleArn") {
+			t.Fatalf("AssumeRole call %d invalid, expected %s signed by %s, got: %s signed by %s",
+				i, e.params.Get("RoleArn"), e.key, actual.params.Get("RoleArn"), actual.key)
+		}
+	}
+}
+
+// func ValidateChain(chain []string) error
+func TestValidateChain(t *testing.T) {
+	if err := ValidateChain([]string{"arn:aws:iam::111111111111:role/hub", "arn:aws-us-gov:iam::222222222222:role/path/spoke"}); err != nil {
+		t.Fatalf("ValidateChain failed: %v", err)
+	}
+	err := ValidateChain([]string{"arn:aws:iam::111111111111:role/hub", "hub"})
+	if err == nil || !strings.Contains(err.Error(), `invalid role chain ARN "hub"`) {
+		t.Fatalf("ValidateChain should fail for a role name, got: %v", err)
+	}
+	if account := ChainAccount([]string{"arn:aws:iam::111111111111:role/hub", "arn:aws:iam::222222222222:role/spoke"}); account != "222222222222" {
+		t.Fatalf("ChainAccount invalid, expected: 222222222222, got: %s", account)
+	}
+}
+
+// func ValidateRoles(roles map[string]Role) error
+func TestValidateRoles(t *testing.T) {
+	tt := map[string]struct {
+		roles       map[string]Role
+		expectedErr string
+	}{
+		"valid": {roles: map[string]Role{"111111111111": {Name: "a", ExternalID: "ext-1", SessionName: "s1", Duration: time.Hour}}},
+		"name": {
+			roles:       map[string]Role{"111111111111": {}, "222222222222": {Name: "bad role"}},
+			expectedErr: `invalid assume role settings for account 222222222222: invalid role name "bad role"`,
+		},
+		"external id": {
+			roles:       map[string]Role{"111111111111": {ExternalID: "x"}},
+			expectedErr: `invalid assume role settings for account 111111111111: invalid external ID "x"`,
+		},
+		"session name": {
+			roles:       map[string]Role{"111111111111": {SessionName: "a b"}},
+			expectedErr: `invalid assume role settings for account 111111111111: invalid session name "a b"`,
+		},
+		"duration": {
+			roles:       map[string]Role{"111111111111": {Duration: time.Minute}},
+			expectedErr: "invalid assume role settings for account 111111111111: invalid session duration 1m0s, must be between 15m0s and 12h0m0s",
+		},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := ValidateRoles(tc.roles)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateRoles failed: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("ValidateRoles failed, expected: %s, got: %v", tc.expectedErr, err)
+			}
+		})
 	}
 }
@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"context"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
@@ -12,9 +14,9 @@ type Ec2Svc struct {
 }
 
 // Instances ... pages through DescribeInstancesPages and returns all EC2 instances
-func (svc *Ec2Svc) Instances() ([]*ec2.Instance, error) {
+func (svc *Ec2Svc) Instances(ctx context.Context) ([]*ec2.Instance, error) {
 	var results []*ec2.Reservation
-	err := svc.Client.DescribeInstancesPages(&ec2.DescribeInstancesInput{},
+	err := svc.Client.DescribeInstancesPagesWithContext(ctx, &ec2.DescribeInstancesInput{},
 		func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
 			results = append(results, page.Reservations...)
 			return !lastPage
@@ -30,9 +32,9 @@ func (svc *Ec2Svc) Instances() ([]*ec2.Instance, error) {
 }
 
 // Images ... performs DescribeImages and returns all EC2 images
-func (svc *Ec2Svc) Images() ([]*ec2.Image, error) {
+func (svc *Ec2Svc) Images(ctx context.Context) ([]*ec2.Image, error) {
 	input := &ec2.DescribeImagesInput{Owners: self}
-	result, err := svc.Client.DescribeImages(input)
+	result, err := svc.Client.DescribeImagesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -40,9 +42,9 @@ func (svc *Ec2Svc) Images() ([]*ec2.Image, error) {
 }
 
 // Volumes ... pages through DescribeVolumesPages and returns all EBS volumes
-func (svc *Ec2Svc) Volumes() ([]*ec2.Volume, error) {
+func (svc *Ec2Svc) Volumes(ctx context.Context) ([]*ec2.Volume, error) {
 	var results []*ec2.Volume
-	err := svc.Client.DescribeVolumesPages(&ec2.DescribeVolumesInput{},
+	err := svc.Client.DescribeVolumesPagesWithContext(ctx, &ec2.DescribeVolumesInput{},
 		func(page *ec2.DescribeVolumesOutput, lastPage bool) bool {
 			results = append(results, page.Volumes...)
 			return !lastPage
@@ -54,10 +56,10 @@ func (svc *Ec2Svc) Volumes() ([]*ec2.Volume, error) {
 }
 
 // Snapshots ... pages through DescribeSnapshotsPages and returns all EBS snapshots
-func (svc *Ec2Svc) Snapshots() ([]*ec2.Snapshot, error) {
+func (svc *Ec2Svc) Snapshots(ctx context.Context) ([]*ec2.Snapshot, error) {
 	var results []*ec2.Snapshot
 	input := &ec2.DescribeSnapshotsInput{OwnerIds: self}
-	err := svc.Client.DescribeSnapshotsPages(input,
+	err := svc.Client.DescribeSnapshotsPagesWithContext(ctx, input,
 		func(page *ec2.DescribeSnapshotsOutput, lastPage bool) bool {
 			results = append(results, page.Snapshots...)
 			return !lastPage
@@ -69,9 +71,9 @@ func (svc *Ec2Svc) Snapshots() ([]*ec2.Snapshot, error) {
 }
 
 // Vpcs ... pages through DescribeVpcsPages and returns all VPCs
-func (svc *Ec2Svc) Vpcs() ([]*ec2.Vpc, error) {
+func (svc *Ec2Svc) Vpcs(ctx context.Context) ([]*ec2.Vpc, error) {
 	var results []*ec2.Vpc
-	err := svc.Client.DescribeVpcsPages(&ec2.DescribeVpcsInput{},
+	err := svc.Client.DescribeVpcsPagesWithContext(ctx, &ec2.DescribeVpcsInput{},
 		func(page *ec2.DescribeVpcsOutput, lastPage bool) bool {
 			results = append(results, page.Vpcs...)
 			return !lastPage
@@ -94,9 +96,9 @@ type VpcPeer struct {
 }
 
 // VpcPeers ... pages through DescribeVpcPeeringConnectionsPages and returns all VPC Peers
-func (svc *Ec2Svc) VpcPeers() ([]*VpcPeer, error) {
+func (svc *Ec2Svc) VpcPeers(ctx context.Context) ([]*VpcPeer, error) {
 	var results []*VpcPeer
-	err := svc.Client.DescribeVpcPeeringConnectionsPages(&ec2.DescribeVpcPeeringConnectionsInput{},
+	err := svc.Client.DescribeVpcPeeringConnectionsPagesWithContext(ctx, &ec2.DescribeVpcPeeringConnectionsInput{},
 		func(page *ec2.DescribeVpcPeeringConnectionsOutput, lastPage bool) bool {
 			for _, conn := range page.VpcPeeringConnections {
 				peer := &VpcPeer{
@@ -120,9 +122,9 @@ func (svc *Ec2Svc) VpcPeers() ([]*VpcPeer, error) {
 }
 
 // Subnets ... pages through DescribeSubnetsPages and returns all VPC Subnets
-func (svc *Ec2Svc) Subnets() ([]*ec2.Subnet, error) {
+func (svc *Ec2Svc) Subnets(ctx context.Context) ([]*ec2.Subnet, error) {
 	var results []*ec2.Subnet
-	err := svc.Client.DescribeSubnetsPages(&ec2.DescribeSubnetsInput{},
+	err := svc.Client.DescribeSubnetsPagesWithContext(ctx, &ec2.DescribeSubnetsInput{},
 		func(page *ec2.DescribeSubnetsOutput, lastPage bool) bool {
 			results = append(results, page.Subnets...)
 			return !lastPage
@@ -133,6 +135,24 @@ func (svc *Ec2Svc) Subnets() ([]*ec2.Subnet, error) {
 	return results, nil
 }
 
+// AccountRegion ... a region of an account, its opt-in status and whether it was scanned
+type AccountRegion struct {
+	AccountID   string
+	AccountName string
+	Region      string
+	OptInStatus string
+	Scanned     bool
+}
+
+// Regions ... performs DescribeRegions and returns every region of the partition with its opt-in status
+func (svc *Ec2Svc) Regions(ctx context.Context) ([]*ec2.Region, error) {
+	result, err := svc.Client.DescribeRegionsWithContext(ctx, &ec2.DescribeRegionsInput{AllRegions: aws.Bool(true)})
+	if err != nil {
+		return nil, err
+	}
+	return result.Regions, nil
+}
+
 type Igw struct {
 	ID      string
 	OwnerID string
@@ -141,9 +161,9 @@ type Igw struct {
 }
 
 // Igws ... pages through DescribeInternetGatewaysPages and returns all VPC Internet Gateways
-func (svc *Ec2Svc) Igws() ([]*Igw, error) {
+func (svc *Ec2Svc) Igws(ctx context.Context) ([]*Igw, error) {
 	var results []*Igw
-	err := svc.Client.DescribeInternetGatewaysPages(&ec2.DescribeInternetGatewaysInput{},
+	err := svc.Client.DescribeInternetGatewaysPagesWithContext(ctx, &ec2.DescribeInternetGatewaysInput{},
 		func(page *ec2.DescribeInternetGatewaysOutput, lastPage bool) bool {
 			for _, igw := range page.InternetGateways {
 				for _, att := range igw.Attachments {
@@ -164,9 +184,9 @@ func (svc *Ec2Svc) Igws() ([]*Igw, error) {
 }
 
 // SecurityGroups ... pages through DescribeSecurityGroupsPages and returns all SecurityGroups
-func (svc *Ec2Svc) SecurityGroups() ([]*ec2.SecurityGroup, error) {
+func (svc *Ec2Svc) SecurityGroups(ctx context.Context) ([]*ec2.SecurityGroup, error) {
 	var results []*ec2.SecurityGroup
-	err := svc.Client.DescribeSecurityGroupsPages(&ec2.DescribeSecurityGroupsInput{},
+	err := svc.Client.DescribeSecurityGroupsPagesWithContext(ctx, &ec2.DescribeSecurityGroupsInput{},
 		func(page *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool {
 			results = append(results, page.SecurityGroups...)
 			return !lastPage
@@ -178,8 +198,8 @@ func (svc *Ec2Svc) SecurityGroups() ([]*ec2.SecurityGroup, error) {
 }
 
 // Addresses ... performs DescribeAddresses and returns all EC2 Addresses
-func (svc *Ec2Svc) Addresses() ([]*ec2.Address, error) {
-	result, err := svc.Client.DescribeAddresses(&ec2.DescribeAddressesInput{})
+func (svc *Ec2Svc) Addresses(ctx context.Context) ([]*ec2.Address, error) {
+	result, err := svc.Client.DescribeAddressesWithContext(ctx, &ec2.DescribeAddressesInput{})
 	if err != nil {
 		return nil, err
 	}
@@ -187,8 +207,8 @@ func (svc *Ec2Svc) Addresses() ([]*ec2.Address, error) {
 }
 
 // KeyPairs ... performs DescribeKeyPairs and returns all EC2 KeyPairs
-func (svc *Ec2Svc) KeyPairs() ([]*ec2.KeyPairInfo, error) {
-	result, err := svc.Client.DescribeKeyPairs(&ec2.DescribeKeyPairsInput{})
+func (svc *Ec2Svc) KeyPairs(ctx context.Context) ([]*ec2.KeyPairInfo, error) {
+	result, err := svc.Client.DescribeKeyPairsWithContext(ctx, &ec2.DescribeKeyPairsInput{})
 	if err != nil {
 		return nil, err
 	}
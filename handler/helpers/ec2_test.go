@@ -1,9 +1,12 @@
 package helpers
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 )
@@ -12,7 +15,7 @@ type mockEc2Client struct {
 	ec2iface.EC2API
 }
 
-func (m *mockEc2Client) DescribeInstancesPages(in *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+func (m *mockEc2Client) DescribeInstancesPagesWithContext(ctx aws.Context, in *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeInstancesOutput{
 		Reservations: []*ec2.Reservation{
 			{
@@ -21,50 +24,56 @@ func (m *mockEc2Client) DescribeInstancesPages(in *ec2.DescribeInstancesInput, f
 	return nil
 }
 
-func (m *mockEc2Client) DescribeImages(in *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+func (m *mockEc2Client) DescribeImagesWithContext(ctx aws.Context, in *ec2.DescribeImagesInput, opts ...request.Option) (*ec2.DescribeImagesOutput, error) {
 	return &ec2.DescribeImagesOutput{Images: []*ec2.Image{{}}}, nil
 }
 
-func (m *mockEc2Client) DescribeVolumesPages(in *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool) error {
+func (m *mockEc2Client) DescribeVolumesPagesWithContext(ctx aws.Context, in *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{{}},
 	}, true)
 	return nil
 }
 
-func (m *mockEc2Client) DescribeSnapshotsPages(in *ec2.DescribeSnapshotsInput, fn func(*ec2.DescribeSnapshotsOutput, bool) bool) error {
+func (m *mockEc2Client) DescribeSnapshotsPagesWithContext(ctx aws.Context, in *ec2.DescribeSnapshotsInput, fn func(*ec2.DescribeSnapshotsOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeSnapshotsOutput{
 		Snapshots: []*ec2.Snapshot{{}},
 	}, true)
 	return nil
 }
 
-func (m *mockEc2Client) DescribeVpcsPages(in *ec2.DescribeVpcsInput, fn func(*ec2.DescribeVpcsOutput, bool) bool) error {
+func (m *mockEc2Client) DescribeVpcsPagesWithContext(ctx aws.Context, in *ec2.DescribeVpcsInput, fn func(*ec2.DescribeVpcsOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeVpcsOutput{
 		Vpcs: []*ec2.Vpc{{}},
 	}, true)
 	return nil
 }
 
-func (m *mockEc2Client) DescribeSubnetsPages(in *ec2.DescribeSubnetsInput, fn func(*ec2.DescribeSubnetsOutput, bool) bool) error {
+func (m *mockEc2Client) DescribeSubnetsPagesWithContext(ctx aws.Context, in *ec2.DescribeSubnetsInput, fn func(*ec2.DescribeSubnetsOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeSubnetsOutput{
 		Subnets: []*ec2.Subnet{{}},
 	}, true)
 	return nil
 }
 
-func (m *mockEc2Client) DescribeSecurityGroupsPages(in *ec2.DescribeSecurityGroupsInput, fn func(*ec2.DescribeSecurityGroupsOutput, bool) bool) error {
+func (m *mockEc2Client) DescribeRegionsWithContext(ctx aws.Context, in *ec2.DescribeRegionsInput, opts ...request.Option) (*ec2.DescribeRegionsOutput, error) {
+	return &ec2.DescribeRegionsOutput{
+		Regions: []*ec2.Region{{}},
+	}, nil
+}
+
+func (m *mockEc2Client) DescribeSecurityGroupsPagesWithContext(ctx aws.Context, in *ec2.DescribeSecurityGroupsInput, fn func(*ec2.DescribeSecurityGroupsOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeSecurityGroupsOutput{
 		SecurityGroups: []*ec2.SecurityGroup{{}},
 	}, true)
 	return nil
 }
 
-func (m *mockEc2Client) DescribeAddresses(in *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+func (m *mockEc2Client) DescribeAddressesWithContext(ctx aws.Context, in *ec2.DescribeAddressesInput, opts ...request.Option) (*ec2.DescribeAddressesOutput, error) {
 	return &ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{}}}, nil
 }
 
-func (m *mockEc2Client) DescribeKeyPairs(in *ec2.DescribeKeyPairsInput) (*ec2.DescribeKeyPairsOutput, error) {
+func (m *mockEc2Client) DescribeKeyPairsWithContext(ctx aws.Context, in *ec2.DescribeKeyPairsInput, opts ...request.Option) (*ec2.DescribeKeyPairsOutput, error) {
 	return &ec2.DescribeKeyPairsOutput{KeyPairs: []*ec2.KeyPairInfo{{}}}, nil
 }
 
@@ -72,7 +81,7 @@ func (m *mockEc2Client) DescribeKeyPairs(in *ec2.DescribeKeyPairsInput) (*ec2.De
 func TestInstances(t *testing.T) {
 	svc := Ec2Svc{Client: &mockEc2Client{}}
 	expected := []*ec2.Instance{{}}
-	got, err := svc.Instances()
+	got, err := svc.Instances(context.Background())
 	if err != nil {
 		t.Fatalf("Instances() failed: %v", err)
 	}
@@ -89,7 +98,7 @@ func TestInstances(t *testing.T) {
 func TestImages(t *testing.T) {
 	svc := Ec2Svc{Client: &mockEc2Client{}}
 	expected := []*ec2.Image{{}}
-	got, err := svc.Images()
+	got, err := svc.Images(context.Background())
 	if err != nil {
 		t.Fatalf("Images() failed: %v", err)
 	}
@@ -106,7 +115,7 @@ func TestImages(t *testing.T) {
 func TestVolumes(t *testing.T) {
 	svc := Ec2Svc{Client: &mockEc2Client{}}
 	expected := []*ec2.Volume{{}}
-	got, err := svc.Volumes()
+	got, err := svc.Volumes(context.Background())
 	if err != nil {
 		t.Fatalf("Volumes() failed: %v", err)
 	}
@@ -123,7 +132,7 @@ func TestVolumes(t *testing.T) {
 func TestSnapshots(t *testing.T) {
 	svc := Ec2Svc{Client: &mockEc2Client{}}
 	expected := []*ec2.Snapshot{{}}
-	got, err := svc.Snapshots()
+	got, err := svc.Snapshots(context.Background())
 	if err != nil {
 		t.Fatalf("Snapshots() failed: %v", err)
 	}
@@ -140,7 +149,7 @@ func TestSnapshots(t *testing.T) {
 func TestVpcs(t *testing.T) {
 	svc := Ec2Svc{Client: &mockEc2Client{}}
 	expected := []*ec2.Vpc{{}}
-	got, err := svc.Vpcs()
+	got, err := svc.Vpcs(context.Background())
 	if err != nil {
 		t.Fatalf("Vpcs() failed: %v", err)
 	}
@@ -157,7 +166,7 @@ func TestVpcs(t *testing.T) {
 func TestSubnets(t *testing.T) {
 	svc := Ec2Svc{Client: &mockEc2Client{}}
 	expected := []*ec2.Subnet{{}}
-	got, err := svc.Subnets()
+	got, err := svc.Subnets(context.Background())
 	if err != nil {
 		t.Fatalf("Subnets() failed: %v", err)
 	}
@@ -170,11 +179,28 @@ func TestSubnets(t *testing.T) {
 	}
 }
 
+// func Regions() ([]*ec2.Region, error)
+func TestRegions(t *testing.T) {
+	svc := Ec2Svc{Client: &mockEc2Client{}}
+	expected := []*ec2.Region{{}}
+	got, err := svc.Regions(context.Background())
+	if err != nil {
+		t.Fatalf("Regions() failed: %v", err)
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("Regions() failed. Expected: %#v (%T)\nGot: %#v (%T)", expected, expected, got, got)
+	}
+	sheet, err := TypeToSheet([]*AccountRegion{{}})
+	if err != nil || sheet != SheetRegions {
+		t.Fatalf("TypeToSheet failed, expected: %s, got: %s, %v", SheetRegions, sheet, err)
+	}
+}
+
 // func SecurityGroups() ([]*ec2.SecurityGroup, error)
 func TestSecurityGroups(t *testing.T) {
 	svc := Ec2Svc{Client: &mockEc2Client{}}
 	expected := []*ec2.SecurityGroup{{}}
-	got, err := svc.SecurityGroups()
+	got, err := svc.SecurityGroups(context.Background())
 	if err != nil {
 		t.Fatalf("SecurityGroups() failed: %v", err)
 	}
@@ -191,7 +217,7 @@ func TestSecurityGroups(t *testing.T) {
 func TestAddresses(t *testing.T) {
 	svc := Ec2Svc{Client: &mockEc2Client{}}
 	expected := []*ec2.Address{{}}
-	got, err := svc.Addresses()
+	got, err := svc.Addresses(context.Background())
 	if err != nil {
 		t.Fatalf("Addresses() failed: %v", err)
 	}
@@ -208,7 +234,7 @@ func TestAddresses(t *testing.T) {
 func TestKeyPairs(t *testing.T) {
 	svc := Ec2Svc{Client: &mockEc2Client{}}
 	expected := []*ec2.KeyPairInfo{{}}
-	got, err := svc.KeyPairs()
+	got, err := svc.KeyPairs(context.Background())
 	if err != nil {
 		t.Fatalf("KeyPairs() failed: %v", err)
 	}
@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+// CollectionError ... describes a query that was skipped for an account/region,
+// used to populate the Collection Errors sheet. Account holds the account ID
+type CollectionError struct {
+	Account     string
+	AccountName string
+	Region      string
+	Sheet       string
+	Code        string
+	Message     string
+	Timestamp   time.Time
+}
+
+// NewCollectionError ... returns a *CollectionError for the provided error, using the
+// code and message of the underlying awserr.Error when there is one. Queries that were
+// not started because the context was done are given the DeadlineExceeded or Canceled code
+func NewCollectionError(account, region, sheet string, err error) *CollectionError {
+	e := &CollectionError{
+		Account:   account,
+		Region:    region,
+		Sheet:     sheet,
+		Code:      "Unknown",
+		Timestamp: time.Now().UTC(),
+	}
+	if err == nil {
+		return e
+	}
+	e.Message = err.Error()
+	var awsErr awserr.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		e.Code = "DeadlineExceeded"
+	case errors.Is(err, context.Canceled):
+		e.Code = "Canceled"
+	case errors.As(err, &awsErr):
+		e.Code = awsErr.Code()
+		e.Message = awsErr.Message()
+	}
+	return e
+}
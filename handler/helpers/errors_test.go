@@ -0,0 +1,48 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+// func NewCollectionError(account, region, sheet string, err error) *CollectionError
+func TestNewCollectionError(t *testing.T) {
+	tt := map[string]struct {
+		err             error
+		expectedCode    string
+		expectedMessage string
+	}{
+		"nil":      {expectedCode: "Unknown"},
+		"plain":    {err: errors.New("test"), expectedCode: "Unknown", expectedMessage: "test"},
+		"awserr":   {err: awserr.New("AccessDenied", "denied", nil), expectedCode: "AccessDenied", expectedMessage: "denied"},
+		"deadline": {err: context.DeadlineExceeded, expectedCode: "DeadlineExceeded", expectedMessage: "context deadline exceeded"},
+		"canceled": {err: fmt.Errorf("skipped: %w", context.Canceled), expectedCode: "Canceled", expectedMessage: "skipped: context canceled"},
+		"wrapped":  {err: fmt.Errorf("wrapped: %w", awserr.New("AccessDenied", "denied", nil)), expectedCode: "AccessDenied", expectedMessage: "denied"},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := NewCollectionError("a", "us-east-1", SheetRoles, tc.err)
+			if e.Account != "a" || e.Region != "us-east-1" || e.Sheet != SheetRoles {
+				t.Fatalf("NewCollectionError() failed, unexpected location: %#v", e)
+			}
+			if e.Code != tc.expectedCode {
+				t.Errorf("Code invalid, expected: %s, got: %s", tc.expectedCode, e.Code)
+			}
+			if e.Message != tc.expectedMessage {
+				t.Errorf("Message invalid, expected: %s, got: %s", tc.expectedMessage, e.Message)
+			}
+			if e.Timestamp.IsZero() {
+				t.Error("Timestamp was not set")
+			}
+			sheet, err := TypeToSheet([]*CollectionError{e})
+			if err != nil || sheet != SheetErrors {
+				t.Fatalf("TypeToSheet failed, expected: %s, got: %s (%v)", SheetErrors, sheet, err)
+			}
+		})
+	}
+}
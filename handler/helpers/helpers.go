@@ -1,11 +1,13 @@
 package helpers
 
 import (
+	"context"
 	"errors"
 	"log"
 	"reflect"
 	"time"
 
+	"github.com/GSA/grace-inventory/handler/helpers/accounts"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
@@ -111,8 +113,8 @@ type KmsKey struct {
 }
 
 // Buckets ... performs ListBuckets and returns all S3 buckets
-func Buckets(svc s3iface.S3API) ([]*s3.Bucket, error) {
-	result, err := svc.ListBuckets(&s3.ListBucketsInput{})
+func Buckets(ctx context.Context, svc s3iface.S3API) ([]*s3.Bucket, error) {
+	result, err := svc.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
 	if err != nil {
 		return nil, err
 	}
@@ -120,9 +122,9 @@ func Buckets(svc s3iface.S3API) ([]*s3.Bucket, error) {
 }
 
 // Stacks ... pages through DescribeStacksPages and returns all CloudFormation Stacks
-func Stacks(svc cloudformationiface.CloudFormationAPI) ([]*cloudformation.Stack, error) {
+func Stacks(ctx context.Context, svc cloudformationiface.CloudFormationAPI) ([]*cloudformation.Stack, error) {
 	var results []*cloudformation.Stack
-	err := svc.DescribeStacksPages(&cloudformation.DescribeStacksInput{},
+	err := svc.DescribeStacksPagesWithContext(ctx, &cloudformation.DescribeStacksInput{},
 		func(page *cloudformation.DescribeStacksOutput, lastPage bool) bool {
 			results = append(results, page.Stacks...)
 			return !lastPage
@@ -134,9 +136,9 @@ func Stacks(svc cloudformationiface.CloudFormationAPI) ([]*cloudformation.Stack,
 }
 
 // Alarms ... pages through DescribeAlarmsPages and returns all CloudWatch Metric Alarms
-func Alarms(svc cloudwatchiface.CloudWatchAPI) ([]*cloudwatch.MetricAlarm, error) {
+func Alarms(ctx context.Context, svc cloudwatchiface.CloudWatchAPI) ([]*cloudwatch.MetricAlarm, error) {
 	var results []*cloudwatch.MetricAlarm
-	err := svc.DescribeAlarmsPages(&cloudwatch.DescribeAlarmsInput{},
+	err := svc.DescribeAlarmsPagesWithContext(ctx, &cloudwatch.DescribeAlarmsInput{},
 		func(page *cloudwatch.DescribeAlarmsOutput, lastPage bool) bool {
 			results = append(results, page.MetricAlarms...)
 			return !lastPage
@@ -148,16 +150,16 @@ func Alarms(svc cloudwatchiface.CloudWatchAPI) ([]*cloudwatch.MetricAlarm, error
 }
 
 // ConfigRules ... performs DescribeConfigRules and returns all Config Service ConfigRules
-func ConfigRules(svc configserviceiface.ConfigServiceAPI) ([]*configservice.ConfigRule, error) {
+func ConfigRules(ctx context.Context, svc configserviceiface.ConfigServiceAPI) ([]*configservice.ConfigRule, error) {
 	input := &configservice.DescribeConfigRulesInput{}
-	result, err := svc.DescribeConfigRules(input)
+	result, err := svc.DescribeConfigRulesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
 	rules := result.ConfigRules
 	for result.NextToken != nil {
 		input.NextToken = result.NextToken
-		result, err = svc.DescribeConfigRules(input)
+		result, err = svc.DescribeConfigRulesWithContext(ctx, input)
 		if err != nil {
 			return nil, err
 		}
@@ -167,9 +169,9 @@ func ConfigRules(svc configserviceiface.ConfigServiceAPI) ([]*configservice.Conf
 }
 
 // LoadBalancers ... pages through DescribeLoadBalancersPages and returns all ELB v2 LoadBalancers
-func LoadBalancers(svc elbv2iface.ELBV2API) ([]*elbv2.LoadBalancer, error) {
+func LoadBalancers(ctx context.Context, svc elbv2iface.ELBV2API) ([]*elbv2.LoadBalancer, error) {
 	var results []*elbv2.LoadBalancer
-	err := svc.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{},
+	err := svc.DescribeLoadBalancersPagesWithContext(ctx, &elbv2.DescribeLoadBalancersInput{},
 		func(page *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
 			results = append(results, page.LoadBalancers...)
 			return !lastPage
@@ -186,9 +188,9 @@ type GlacierSvc struct {
 }
 
 // Vaults ... pages through ListVaultsPages and returns all Glacier Vaults
-func (svc *GlacierSvc) Vaults() ([]*glacier.DescribeVaultOutput, error) {
+func (svc *GlacierSvc) Vaults(ctx context.Context) ([]*glacier.DescribeVaultOutput, error) {
 	var results []*glacier.DescribeVaultOutput
-	err := svc.Client.ListVaultsPages(&glacier.ListVaultsInput{},
+	err := svc.Client.ListVaultsPagesWithContext(ctx, &glacier.ListVaultsInput{},
 		func(page *glacier.ListVaultsOutput, lastPage bool) bool {
 			results = append(results, page.VaultList...)
 			return !lastPage
@@ -200,16 +202,16 @@ func (svc *GlacierSvc) Vaults() ([]*glacier.DescribeVaultOutput, error) {
 }
 
 // Keys ... pages over ListKeys results and returns all KMS Keys w/ AliasName
-func Keys(svc kmsiface.KMSAPI) ([]*KmsKey, error) {
-	keyList, err := listKeys(svc)
+func Keys(ctx context.Context, svc kmsiface.KMSAPI) ([]*KmsKey, error) {
+	keyList, err := listKeys(ctx, svc)
 	if err != nil {
 		return nil, err
 	}
-	keyDescriptions, err := getKeyDescriptions(svc, keyList)
+	keyDescriptions, err := getKeyDescriptions(ctx, svc, keyList)
 	if err != nil {
 		return nil, err
 	}
-	keyAliases, err := listKeyAliases(svc)
+	keyAliases, err := listKeyAliases(ctx, svc)
 	if err != nil {
 		return nil, err
 	}
@@ -217,9 +219,9 @@ func Keys(svc kmsiface.KMSAPI) ([]*KmsKey, error) {
 }
 
 // listKeys ... pages through ListKeysPages to get list of KeyIDs
-func listKeys(svc kmsiface.KMSAPI) ([]*kms.KeyListEntry, error) {
+func listKeys(ctx context.Context, svc kmsiface.KMSAPI) ([]*kms.KeyListEntry, error) {
 	var results []*kms.KeyListEntry
-	err := svc.ListKeysPages(&kms.ListKeysInput{},
+	err := svc.ListKeysPagesWithContext(ctx, &kms.ListKeysInput{},
 		func(page *kms.ListKeysOutput, lastPage bool) bool {
 			results = append(results, page.Keys...)
 			return !lastPage
@@ -231,11 +233,11 @@ func listKeys(svc kmsiface.KMSAPI) ([]*kms.KeyListEntry, error) {
 }
 
 // getKeyDescriptions ... loops through list of KeyIds to get KeyMetadata (kms.DescribeKey)
-func getKeyDescriptions(svc kmsiface.KMSAPI, keyList []*kms.KeyListEntry) ([]*kms.KeyMetadata, error) {
+func getKeyDescriptions(ctx context.Context, svc kmsiface.KMSAPI, keyList []*kms.KeyListEntry) ([]*kms.KeyMetadata, error) {
 	var keys []*kms.KeyMetadata
 	for _, key := range keyList {
 		input := &kms.DescribeKeyInput{KeyId: key.KeyId}
-		result, err := svc.DescribeKey(input)
+		result, err := svc.DescribeKeyWithContext(ctx, input)
 		if err != nil {
 			return nil, err
 		}
@@ -245,9 +247,9 @@ func getKeyDescriptions(svc kmsiface.KMSAPI, keyList []*kms.KeyListEntry) ([]*km
 }
 
 // listKeyAliases ... pages over ListAliasesPages and returns list of Aliases
-func listKeyAliases(svc kmsiface.KMSAPI) ([]*kms.AliasListEntry, error) {
+func listKeyAliases(ctx context.Context, svc kmsiface.KMSAPI) ([]*kms.AliasListEntry, error) {
 	var results []*kms.AliasListEntry
-	err := svc.ListAliasesPages(&kms.ListAliasesInput{},
+	err := svc.ListAliasesPagesWithContext(ctx, &kms.ListAliasesInput{},
 		func(page *kms.ListAliasesOutput, lastPage bool) bool {
 			results = append(results, page.Aliases...)
 			return !lastPage
@@ -294,9 +296,9 @@ func getAliasName(str string, keyAliases []*kms.AliasListEntry) *string {
 }
 
 // Parameters ... pages through DescribeParametersPages to get SSM Parameters
-func Parameters(svc ssmiface.SSMAPI) ([]*ssm.ParameterMetadata, error) {
+func Parameters(ctx context.Context, svc ssmiface.SSMAPI) ([]*ssm.ParameterMetadata, error) {
 	var results []*ssm.ParameterMetadata
-	err := svc.DescribeParametersPages(&ssm.DescribeParametersInput{},
+	err := svc.DescribeParametersPagesWithContext(ctx, &ssm.DescribeParametersInput{},
 		func(page *ssm.DescribeParametersOutput, lastPage bool) bool {
 			results = append(results, page.Parameters...)
 			return !lastPage
@@ -309,35 +311,41 @@ func Parameters(svc ssmiface.SSMAPI) ([]*ssm.ParameterMetadata, error) {
 
 // Sheet name constants
 const (
-	SheetRoles          = "Roles"
-	SheetAccounts       = "Accounts"
-	SheetGroups         = "Groups"
-	SheetPolicies       = "Policies"
-	SheetUsers          = "Users"
-	SheetBuckets        = "Buckets"
-	SheetInstances      = "Instances"
-	SheetImages         = "Images"
-	SheetVolumes        = "Volumes"
-	SheetSnapshots      = "Snapshots"
-	SheetIgws           = "IGWs"
-	SheetVpcs           = "VPCs"
-	SheetVpcPeers       = "VpcPeers"
-	SheetSubnets        = "Subnets"
-	SheetSecurityGroups = "SecurityGroups"
-	SheetAddresses      = "Addresses"
-	SheetKeyPairs       = "KeyPairs"
-	SheetStacks         = "Stacks"
-	SheetAlarms         = "Alarms"
-	SheetConfigRules    = "ConfigRules"
-	SheetLoadBalancers  = "LoadBlancers"
-	SheetVaults         = "Vaults"
-	SheetKeys           = "Keys"
-	SheetDBInstances    = "DBInstances"
-	SheetDBSnapshots    = "DBSnapshots"
-	SheetSecrets        = "Secrets"
-	SheetSubscriptions  = "Subscriptions"
-	SheetTopics         = "Topics"
-	SheetParameters     = "Parameters"
+	SheetRoles           = "Roles"
+	SheetAccounts        = "Accounts"
+	SheetGroups          = "Groups"
+	SheetPolicies        = "Policies"
+	SheetUsers           = "Users"
+	SheetBuckets         = "Buckets"
+	SheetInstances       = "Instances"
+	SheetImages          = "Images"
+	SheetVolumes         = "Volumes"
+	SheetSnapshots       = "Snapshots"
+	SheetIgws            = "IGWs"
+	SheetVpcs            = "VPCs"
+	SheetVpcPeers        = "VpcPeers"
+	SheetSubnets         = "Subnets"
+	SheetSecurityGroups  = "SecurityGroups"
+	SheetAddresses       = "Addresses"
+	SheetKeyPairs        = "KeyPairs"
+	SheetStacks          = "Stacks"
+	SheetAlarms          = "Alarms"
+	SheetConfigRules     = "ConfigRules"
+	SheetLoadBalancers   = "LoadBlancers"
+	SheetVaults          = "Vaults"
+	SheetKeys            = "Keys"
+	SheetDBInstances     = "DBInstances"
+	SheetDBSnapshots     = "DBSnapshots"
+	SheetSecrets         = "Secrets"
+	SheetSubscriptions   = "Subscriptions"
+	SheetTopics          = "Topics"
+	SheetParameters      = "Parameters"
+	SheetRegions         = "Regions"
+	SheetAccountAccess   = "AccountAccess"
+	SheetOrgUnits        = "OrganizationalUnits"
+	SheetSCPs            = "ServiceControlPolicies"
+	SheetDelegatedAdmins = "DelegatedAdministrators"
+	SheetErrors          = "Errors"
 )
 
 // nolint: gocyclo
@@ -355,7 +363,7 @@ func TypeToSheet(items interface{}) (string, error) {
 		return "", nil
 	}
 	switch val := s.Index(0).Interface().(type) {
-	case *organizations.Account:
+	case *organizations.Account, *accounts.Account:
 		sheet = SheetAccounts
 	case *iam.Role:
 		sheet = SheetRoles
@@ -413,6 +421,18 @@ func TypeToSheet(items interface{}) (string, error) {
 		sheet = SheetParameters
 	case *VpcPeer:
 		sheet = SheetVpcPeers
+	case *AccountRegion:
+		sheet = SheetRegions
+	case *AccountAccess:
+		sheet = SheetAccountAccess
+	case *accounts.OrganizationalUnit:
+		sheet = SheetOrgUnits
+	case *accounts.Policy:
+		sheet = SheetSCPs
+	case *accounts.DelegatedAdministrator:
+		sheet = SheetDelegatedAdmins
+	case *CollectionError:
+		sheet = SheetErrors
 	default:
 		log.Printf("Unknown sheet type: %T", val)
 		return "", errors.New("unknown type")
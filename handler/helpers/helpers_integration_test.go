@@ -3,6 +3,7 @@
 package helpers
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -23,319 +24,319 @@ import (
 
 const defaultRegion = "us-east-1"
 
-// func Roles(sess *session.Session, cred *credentials.Credentials) ([]*iam.Role, error)
+// func (svc *IamSvc) Roles(ctx context.Context) ([]*iam.Role, error)
 func TestIntegrationRoles(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := IamSvc{Client: iam.New(sess)}
-	_, err = svc.Roles()
+	_, err = svc.Roles(context.Background())
 	if err != nil {
 		t.Fatalf("Roles() failed: %v", err)
 	}
 }
 
-// func Groups(sess *session.Session, cred *credentials.Credentials) ([]*iam.Group, error)
+// func (svc *IamSvc) Groups(ctx context.Context) ([]*iam.Group, error)
 func TestIntegrationGroups(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := IamSvc{Client: iam.New(sess)}
-	_, err = svc.Groups()
+	_, err = svc.Groups(context.Background())
 	if err != nil {
 		t.Fatalf("Groups() failed: %v", err)
 	}
 }
 
-// func Policies(sess *session.Session, cred *credentials.Credentials) ([]*iam.Policy, error)
+// func (svc *IamSvc) Policies(ctx context.Context) ([]*iam.Policy, error)
 func TestIntegrationPolicies(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := IamSvc{Client: iam.New(sess)}
-	_, err = svc.Policies()
+	_, err = svc.Policies(context.Background())
 	if err != nil {
 		t.Fatalf("Policies() failed: %v", err)
 	}
 }
 
-// func Users(sess *session.Session, cred *credentials.Credentials) ([]*iam.User, error)
+// func (svc *IamSvc) Users(ctx context.Context) ([]*iam.User, error)
 func TestIntegrationUsers(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := IamSvc{Client: iam.New(sess)}
-	_, err = svc.Users()
+	_, err = svc.Users(context.Background())
 	if err != nil {
 		t.Fatalf("Users() failed: %v", err)
 	}
 }
 
-// func Buckets(sess *session.Session, cred *credentials.Credentials) ([]*s3.Bucket, error)
+// func Buckets(ctx context.Context, svc s3iface.S3API) ([]*s3.Bucket, error)
 func TestIntegrationBuckets(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := s3.New(sess)
-	_, err = Buckets(svc)
+	_, err = Buckets(context.Background(), svc)
 	if err != nil {
 		t.Fatalf("Buckets() failed: %v", err)
 	}
 }
 
-// func Instances(sess *session.Session, cred *credentials.Credentials) ([]*ec2.Instance, error)
+// func (svc *Ec2Svc) Instances(ctx context.Context) ([]*ec2.Instance, error)
 func TestIntegrationInstances(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := Ec2Svc{Client: ec2.New(sess)}
-	_, err = svc.Instances()
+	_, err = svc.Instances(context.Background())
 	if err != nil {
 		t.Fatalf("Instances() failed: %v", err)
 	}
 }
 
-// func Images(sess *session.Session, cred *credentials.Credentials) ([]*ec2.Image, error)
+// func (svc *Ec2Svc) Images(ctx context.Context) ([]*ec2.Image, error)
 func TestIntegrationImages(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := Ec2Svc{Client: ec2.New(sess)}
-	_, err = svc.Images()
+	_, err = svc.Images(context.Background())
 	if err != nil {
 		t.Fatalf("Images() failed: %v", err)
 	}
 }
 
-// func Volumes(sess *session.Session, cred *credentials.Credentials) ([]*ec2.Volume, error)
+// func (svc *Ec2Svc) Volumes(ctx context.Context) ([]*ec2.Volume, error)
 func TestIntegrationVolumes(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := Ec2Svc{Client: ec2.New(sess)}
-	_, err = svc.Volumes()
+	_, err = svc.Volumes(context.Background())
 	if err != nil {
 		t.Fatalf("Volumes() failed: %v", err)
 	}
 }
 
-// func Snapshots(sess *session.Session, cred *credentials.Credentials) ([]*ec2.Snapshot, error)
+// func (svc *Ec2Svc) Snapshots(ctx context.Context) ([]*ec2.Snapshot, error)
 func TestIntegrationSnapshots(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := Ec2Svc{Client: ec2.New(sess)}
-	_, err = svc.Snapshots()
+	_, err = svc.Snapshots(context.Background())
 	if err != nil {
 		t.Fatalf("Snapshots() failed: %v", err)
 	}
 }
 
-// func Vpcs(sess *session.Session, cred *credentials.Credentials) ([]*ec2.Vpc, error)
+// func (svc *Ec2Svc) Vpcs(ctx context.Context) ([]*ec2.Vpc, error)
 func TestIntegrationVpcs(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := Ec2Svc{Client: ec2.New(sess)}
-	_, err = svc.Vpcs()
+	_, err = svc.Vpcs(context.Background())
 	if err != nil {
 		t.Fatalf("Vpcs() failed: %v", err)
 	}
 }
 
-// func Subnets(sess *session.Session, cred *credentials.Credentials) ([]*ec2.Subnet, error)
+// func (svc *Ec2Svc) Subnets(ctx context.Context) ([]*ec2.Subnet, error)
 func TestIntegrationSubnets(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := Ec2Svc{Client: ec2.New(sess)}
-	_, err = svc.Subnets()
+	_, err = svc.Subnets(context.Background())
 	if err != nil {
 		t.Fatalf("Subnets() failed: %v", err)
 	}
 }
 
-// func SecurityGroups(sess *session.Session, cred *credentials.Credentials) ([]*ec2.SecurityGroup, error)
+// func (svc *Ec2Svc) SecurityGroups(ctx context.Context) ([]*ec2.SecurityGroup, error)
 func TestIntegrationSecurityGroups(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := Ec2Svc{Client: ec2.New(sess)}
-	_, err = svc.SecurityGroups()
+	_, err = svc.SecurityGroups(context.Background())
 	if err != nil {
 		t.Fatalf("SecurityGroups() failed: %v", err)
 	}
 }
 
-// func Addresses(sess *session.Session, cred *credentials.Credentials) ([]*ec2.Address, error)
+// func (svc *Ec2Svc) Addresses(ctx context.Context) ([]*ec2.Address, error)
 func TestIntegrationAddresses(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := Ec2Svc{Client: ec2.New(sess)}
-	_, err = svc.Addresses()
+	_, err = svc.Addresses(context.Background())
 	if err != nil {
 		t.Fatalf("Addresses() failed: %v", err)
 	}
 }
 
-// func KeyPairs(sess *session.Session, cred *credentials.Credentials) ([]*ec2.KeyPairInfo, error)
+// func (svc *Ec2Svc) KeyPairs(ctx context.Context) ([]*ec2.KeyPairInfo, error)
 func TestIntegrationKeyPairs(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := Ec2Svc{Client: ec2.New(sess)}
-	_, err = svc.KeyPairs()
+	_, err = svc.KeyPairs(context.Background())
 	if err != nil {
 		t.Fatalf("KeyPairs() failed: %v", err)
 	}
 }
 
-// func Stacks(sess *session.Session, cred *credentials.Credentials) ([]*cloudformation.Stack, error)
+// func Stacks(ctx context.Context, svc cloudformationiface.CloudFormationAPI) ([]*cloudformation.Stack, error)
 func TestIntegrationStacks(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
-	_, err = Stacks(cloudformation.New(sess))
+	_, err = Stacks(context.Background(), cloudformation.New(sess))
 	if err != nil {
 		t.Fatalf("Stacks() failed: %v", err)
 	}
 }
 
-// func Alarms(sess *session.Session, cred *credentials.Credentials) ([]*cloudwatch.MetricAlarm, error)
+// func Alarms(ctx context.Context, svc cloudwatchiface.CloudWatchAPI) ([]*cloudwatch.MetricAlarm, error)
 func TestIntegrationAlarms(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
-	_, err = Alarms(cloudwatch.New(sess))
+	_, err = Alarms(context.Background(), cloudwatch.New(sess))
 	if err != nil {
 		t.Fatalf("Alarms() failed: %v", err)
 	}
 }
 
-// func ConfigRules(sess *session.Session, cred *credentials.Credentials) ([]*configservice.ConfigRule, error)
+// func ConfigRules(ctx context.Context, svc configserviceiface.ConfigServiceAPI) ([]*configservice.ConfigRule, error)
 func TestIntegrationConfigRules(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
-	_, err = ConfigRules(configservice.New(sess))
+	_, err = ConfigRules(context.Background(), configservice.New(sess))
 	if err != nil {
 		t.Fatalf("ConfigRules() failed: %v", err)
 	}
 }
 
-// func LoadBalancers(sess *session.Session, cred *credentials.Credentials) ([]*elbv2.LoadBalancer, error)
+// func LoadBalancers(ctx context.Context, svc elbv2iface.ELBV2API) ([]*elbv2.LoadBalancer, error)
 func TestIntegrationLoadBalancers(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
-	_, err = LoadBalancers(elbv2.New(sess))
+	_, err = LoadBalancers(context.Background(), elbv2.New(sess))
 	if err != nil {
 		t.Fatalf("LoadBalancers() failed: %v", err)
 	}
 }
 
-// func Vaults(sess *session.Session, cred *credentials.Credentials) ([]*glacier.DescribeVaultOutput, error)
+// func (svc *GlacierSvc) Vaults(ctx context.Context) ([]*glacier.DescribeVaultOutput, error)
 func TestVaults(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := &GlacierSvc{Client: glacier.New(sess)}
-	_, err = svc.Vaults()
+	_, err = svc.Vaults(context.Background())
 	if err != nil {
 		t.Fatalf("Vaults() failed: %v", err)
 	}
 }
 
-// func Keys(cfg client.ConfigProvider, cred *credentials.Credentials) ([]*KmsKey, error) {
+// func Keys(ctx context.Context, svc kmsiface.KMSAPI) ([]*KmsKey, error)
 func TestIntegrationKeys(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
-	_, err = Keys(kms.New(sess))
+	_, err = Keys(context.Background(), kms.New(sess))
 	if err != nil {
 		t.Fatalf("Keys() failed: %v", err)
 	}
 }
 
-// func DBInstances(cfg client.ConfigProvider, cred *credentials.Credentials) ([]*rds.DBInstance, error) {
+// func (svc RDSSvc) DBInstances(ctx context.Context) ([]*rds.DBInstance, error)
 func TestIntegrationDBInstances(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := &RDSSvc{Client: rds.New(sess)}
-	_, err = svc.DBInstances()
+	_, err = svc.DBInstances(context.Background())
 	if err != nil {
 		t.Fatalf("DBInstances() failed: %v", err)
 	}
 }
 
-// func DBSnapshots(cfg client.ConfigProvider, cred *credentials.Credentials) ([]*rds.DBInstance, error) {
+// func (svc RDSSvc) DBSnapshots(ctx context.Context) ([]*rds.DBSnapshot, error)
 func TestIntegrationDBSnapshots(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := &RDSSvc{Client: rds.New(sess)}
-	_, err = svc.DBSnapshots()
+	_, err = svc.DBSnapshots(context.Background())
 	if err != nil {
 		t.Fatalf("DBSnapshots() failed: %v", err)
 	}
 }
 
-// func Secrets(cfg client.ConfigProvider, cred *credentials.Credentials) ([]*secretsmanager.SecretListEntry, error) {
+// func (svc SecretsManagerSvc) Secrets(ctx context.Context) ([]*secretsmanager.SecretListEntry, error)
 func TestIntegrationSecrets(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
 	svc := &SecretsManagerSvc{Client: secretsmanager.New(sess)}
-	_, err = svc.Secrets()
+	_, err = svc.Secrets(context.Background())
 	if err != nil {
 		t.Fatalf("Secrets() failed: %v", err)
 	}
 }
 
-// func Subscriptions(cfg client.ConfigProvider, cred *credentials.Credentials) ([]*sns.Subscription, error) {
+// func Subscriptions(ctx context.Context, svc snsiface.SNSAPI) ([]*sns.Subscription, error)
 func TestIntegrationSubscriptions(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
-	_, err = Subscriptions(sns.New(sess))
+	_, err = Subscriptions(context.Background(), sns.New(sess))
 	if err != nil {
 		t.Fatalf("Subscriptions() failed: %v", err)
 	}
 }
 
-// func Topics(cfg client.ConfigProvider, cred *credentials.Credentials) ([]*SnsTopic, error) {
+// func Topics(ctx context.Context, svc snsiface.SNSAPI) ([]*SnsTopic, error)
 func TestIntegrationTopics(t *testing.T) {
 	sess, err := awstest.NewAuthenticatedSession(defaultRegion)
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
-	_, err = Topics(sns.New(sess))
+	_, err = Topics(context.Background(), sns.New(sess))
 	if err != nil {
 		t.Fatalf("Topics() failed: %v", err)
 	}
@@ -347,7 +348,7 @@ func TestIntegrationParameters(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
-	_, err = Parameters(ssm.New(sess))
+	_, err = Parameters(context.Background(), ssm.New(sess))
 	if err != nil {
 		t.Fatalf("Parameters() failed: %v", err)
 	}
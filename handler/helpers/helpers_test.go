@@ -1,13 +1,16 @@
 package helpers
 
 import (
+	"context"
 	"errors"
 
 	"reflect"
 	"strconv"
 	"testing"
 
+	"github.com/GSA/grace-inventory/handler/helpers/accounts"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
@@ -20,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/glacier/glacieriface"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/ssm"
@@ -30,7 +34,7 @@ type mockS3Client struct {
 	s3iface.S3API
 }
 
-func (m mockS3Client) ListBuckets(in *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
+func (m mockS3Client) ListBucketsWithContext(ctx aws.Context, in *s3.ListBucketsInput, opts ...request.Option) (*s3.ListBucketsOutput, error) {
 	return &s3.ListBucketsOutput{Buckets: []*s3.Bucket{{}}}, nil
 }
 
@@ -38,7 +42,7 @@ type mockCFClient struct {
 	cloudformationiface.CloudFormationAPI
 }
 
-func (m mockCFClient) DescribeStacksPages(in *cloudformation.DescribeStacksInput, fn func(*cloudformation.DescribeStacksOutput, bool) bool) error {
+func (m mockCFClient) DescribeStacksPagesWithContext(ctx aws.Context, in *cloudformation.DescribeStacksInput, fn func(*cloudformation.DescribeStacksOutput, bool) bool, opts ...request.Option) error {
 	fn(&cloudformation.DescribeStacksOutput{Stacks: []*cloudformation.Stack{{}}}, true)
 	return nil
 }
@@ -47,7 +51,7 @@ type mockCWClient struct {
 	cloudwatchiface.CloudWatchAPI
 }
 
-func (m mockCWClient) DescribeAlarmsPages(in *cloudwatch.DescribeAlarmsInput, fn func(*cloudwatch.DescribeAlarmsOutput, bool) bool) error {
+func (m mockCWClient) DescribeAlarmsPagesWithContext(ctx aws.Context, in *cloudwatch.DescribeAlarmsInput, fn func(*cloudwatch.DescribeAlarmsOutput, bool) bool, opts ...request.Option) error {
 	fn(&cloudwatch.DescribeAlarmsOutput{MetricAlarms: []*cloudwatch.MetricAlarm{{}}}, true)
 	return nil
 }
@@ -56,7 +60,7 @@ type mockCSClient struct {
 	configserviceiface.ConfigServiceAPI
 }
 
-func (m mockCSClient) DescribeConfigRules(in *configservice.DescribeConfigRulesInput) (*configservice.DescribeConfigRulesOutput, error) {
+func (m mockCSClient) DescribeConfigRulesWithContext(ctx aws.Context, in *configservice.DescribeConfigRulesInput, opts ...request.Option) (*configservice.DescribeConfigRulesOutput, error) {
 	return &configservice.DescribeConfigRulesOutput{ConfigRules: []*configservice.ConfigRule{{}}}, nil
 }
 
@@ -64,7 +68,7 @@ type mockElbClient struct {
 	elbv2iface.ELBV2API
 }
 
-func (m mockElbClient) DescribeLoadBalancersPages(in *elbv2.DescribeLoadBalancersInput, fn func(*elbv2.DescribeLoadBalancersOutput, bool) bool) error {
+func (m mockElbClient) DescribeLoadBalancersPagesWithContext(ctx aws.Context, in *elbv2.DescribeLoadBalancersInput, fn func(*elbv2.DescribeLoadBalancersOutput, bool) bool, opts ...request.Option) error {
 	fn(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{{}}}, true)
 	return nil
 }
@@ -73,16 +77,16 @@ type mockKmsClient struct {
 	kmsiface.KMSAPI
 }
 
-func (m mockKmsClient) ListKeysPages(in *kms.ListKeysInput, fn func(*kms.ListKeysOutput, bool) bool) error {
+func (m mockKmsClient) ListKeysPagesWithContext(ctx aws.Context, in *kms.ListKeysInput, fn func(*kms.ListKeysOutput, bool) bool, opts ...request.Option) error {
 	fn(&kms.ListKeysOutput{Keys: []*kms.KeyListEntry{{}}}, true)
 	return nil
 }
 
-func (m mockKmsClient) DescribeKey(in *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+func (m mockKmsClient) DescribeKeyWithContext(ctx aws.Context, in *kms.DescribeKeyInput, opts ...request.Option) (*kms.DescribeKeyOutput, error) {
 	return &kms.DescribeKeyOutput{KeyMetadata: &kms.KeyMetadata{}}, nil
 }
 
-func (m mockKmsClient) ListAliasesPages(in *kms.ListAliasesInput, fn func(*kms.ListAliasesOutput, bool) bool) error {
+func (m mockKmsClient) ListAliasesPagesWithContext(ctx aws.Context, in *kms.ListAliasesInput, fn func(*kms.ListAliasesOutput, bool) bool, opts ...request.Option) error {
 	fn(&kms.ListAliasesOutput{Aliases: []*kms.AliasListEntry{{}}}, true)
 	return nil
 }
@@ -91,7 +95,7 @@ type mockSsmClient struct {
 	ssmiface.SSMAPI
 }
 
-func (m mockSsmClient) DescribeParametersPages(in *ssm.DescribeParametersInput, fn func(*ssm.DescribeParametersOutput, bool) bool) error {
+func (m mockSsmClient) DescribeParametersPagesWithContext(ctx aws.Context, in *ssm.DescribeParametersInput, fn func(*ssm.DescribeParametersOutput, bool) bool, opts ...request.Option) error {
 	fn(&ssm.DescribeParametersOutput{Parameters: []*ssm.ParameterMetadata{{}}}, true)
 	return nil
 }
@@ -100,7 +104,7 @@ func (m mockSsmClient) DescribeParametersPages(in *ssm.DescribeParametersInput,
 func TestBuckets(t *testing.T) {
 	svc := mockS3Client{}
 	expected := []*s3.Bucket{{}}
-	got, err := Buckets(svc)
+	got, err := Buckets(context.Background(), svc)
 	if err != nil {
 		t.Fatalf("Buckets() failed: %v", err)
 	}
@@ -117,7 +121,7 @@ func TestBuckets(t *testing.T) {
 func TestStacks(t *testing.T) {
 	expected := []*cloudformation.Stack{{}}
 	svc := mockCFClient{}
-	got, err := Stacks(svc)
+	got, err := Stacks(context.Background(), svc)
 	if err != nil {
 		t.Fatalf("Stacks() failed: %v", err)
 	}
@@ -134,7 +138,7 @@ func TestStacks(t *testing.T) {
 func TestAlarms(t *testing.T) {
 	expected := []*cloudwatch.MetricAlarm{{}}
 	svc := mockCWClient{}
-	got, err := Alarms(svc)
+	got, err := Alarms(context.Background(), svc)
 	if err != nil {
 		t.Fatalf("Alarms() failed: %v", err)
 	}
@@ -151,7 +155,7 @@ func TestAlarms(t *testing.T) {
 func TestConfigRules(t *testing.T) {
 	expected := []*configservice.ConfigRule{{}}
 	svc := mockCSClient{}
-	got, err := ConfigRules(svc)
+	got, err := ConfigRules(context.Background(), svc)
 	if err != nil {
 		t.Fatalf("ConfigRules() failed: %v", err)
 	}
@@ -168,7 +172,7 @@ func TestConfigRules(t *testing.T) {
 func TestLoadBalancers(t *testing.T) {
 	expected := []*elbv2.LoadBalancer{{}}
 	svc := mockElbClient{}
-	got, err := LoadBalancers(svc)
+	got, err := LoadBalancers(context.Background(), svc)
 	if err != nil {
 		t.Fatalf("LoadBalancers() failed: %v", err)
 	}
@@ -186,7 +190,7 @@ type mockGlacierClient struct {
 	pages int
 }
 
-func (m *mockGlacierClient) ListVaultsPages(in *glacier.ListVaultsInput, fn func(*glacier.ListVaultsOutput, bool) bool) error {
+func (m *mockGlacierClient) ListVaultsPagesWithContext(ctx aws.Context, in *glacier.ListVaultsInput, fn func(*glacier.ListVaultsOutput, bool) bool, opts ...request.Option) error {
 	for i := 0; i < m.pages; i++ {
 		if !fn(m.listVaultsPagesR(in, i)) {
 			return nil
@@ -226,7 +230,7 @@ func (m *mockGlacierClient) listVaultsPagesR(in *glacier.ListVaultsInput, index
 
 func TestVaultsErr(t *testing.T) {
 	svc := &GlacierSvc{Client: &mockGlacierClient{pages: 0}}
-	_, err := svc.Vaults()
+	_, err := svc.Vaults(context.Background())
 	if err == nil {
 		t.Error("err value was nil when failure was expected")
 	}
@@ -249,7 +253,7 @@ func TestVaultsPagination(t *testing.T) {
 		tc := st
 		t.Run(tc.Name, func(t *testing.T) {
 			svc := &GlacierSvc{Client: &mockGlacierClient{pages: tc.Pages}}
-			items, err := svc.Vaults()
+			items, err := svc.Vaults(context.Background())
 			if err != nil {
 				t.Fatalf("Vaults() failed: %v", err)
 			}
@@ -280,7 +284,7 @@ func TestVaultsPagination(t *testing.T) {
 func TestKeys(t *testing.T) {
 	expected := []*KmsKey{{}}
 	svc := mockKmsClient{}
-	got, err := Keys(svc)
+	got, err := Keys(context.Background(), svc)
 	if err != nil {
 		t.Fatalf("Keys() failed: %v", err)
 	}
@@ -298,7 +302,7 @@ func TestDBInstances(t *testing.T) {
 	svc := RDSSvc{
 		Client: mockedRDS{},
 	}
-	items, err := svc.DBInstances()
+	items, err := svc.DBInstances(context.Background())
 	if err != nil {
 		t.Fatalf("DBInstances() failed: %v", err)
 	}
@@ -313,7 +317,7 @@ func TestDBSnapshots(t *testing.T) {
 	svc := RDSSvc{
 		Client: mockedRDS{},
 	}
-	items, err := svc.DBSnapshots()
+	items, err := svc.DBSnapshots(context.Background())
 	if err != nil {
 		t.Fatalf("DBSnapshots() failed: %v", err)
 	}
@@ -328,7 +332,7 @@ func TestSecrets(t *testing.T) {
 	svc := SecretsManagerSvc{
 		Client: mockedSecretsManager{},
 	}
-	items, err := svc.Secrets()
+	items, err := svc.Secrets(context.Background())
 	if err != nil {
 		t.Fatalf("Secrets() failed: %v", err)
 	}
@@ -342,7 +346,7 @@ func TestSecrets(t *testing.T) {
 func TestParameters(t *testing.T) {
 	expected := []*ssm.ParameterMetadata{{}}
 	svc := mockSsmClient{}
-	got, err := Parameters(svc)
+	got, err := Parameters(context.Background(), svc)
 	if err != nil {
 		t.Fatalf("Parameters() failed: %v", err)
 	}
@@ -354,3 +358,23 @@ func TestParameters(t *testing.T) {
 		t.Fatalf("TypeToSheet failed: %v", err)
 	}
 }
+
+// func TypeToSheet(items interface{}) (string, error)
+func TestTypeToSheetOrganizations(t *testing.T) {
+	tt := map[string]interface{}{
+		SheetAccounts:        []interface{}{&organizations.Account{}},
+		SheetOrgUnits:        []interface{}{&accounts.OrganizationalUnit{}},
+		SheetSCPs:            []interface{}{&accounts.Policy{}},
+		SheetDelegatedAdmins: []interface{}{&accounts.DelegatedAdministrator{}},
+	}
+	for expected, items := range tt {
+		sheet, err := TypeToSheet(items)
+		if err != nil || sheet != expected {
+			t.Fatalf("TypeToSheet failed, expected: %s, got: %s, %v", expected, sheet, err)
+		}
+	}
+	sheet, err := TypeToSheet([]interface{}{&accounts.Account{Account: &organizations.Account{}, OUPath: "Root"}})
+	if err != nil || sheet != SheetAccounts {
+		t.Fatalf("TypeToSheet failed, expected: %s, got: %s, %v", SheetAccounts, sheet, err)
+	}
+}
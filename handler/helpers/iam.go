@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"context"
+
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 )
@@ -11,9 +13,9 @@ type IamSvc struct {
 }
 
 // Roles ... pages through ListRolesPages and returns all IAM roles
-func (svc *IamSvc) Roles() ([]*iam.Role, error) {
+func (svc *IamSvc) Roles(ctx context.Context) ([]*iam.Role, error) {
 	var results []*iam.Role
-	err := svc.Client.ListRolesPages(&iam.ListRolesInput{},
+	err := svc.Client.ListRolesPagesWithContext(ctx, &iam.ListRolesInput{},
 		func(page *iam.ListRolesOutput, lastPage bool) bool {
 			results = append(results, page.Roles...)
 			return !lastPage
@@ -25,9 +27,9 @@ func (svc *IamSvc) Roles() ([]*iam.Role, error) {
 }
 
 // Groups ... pages through ListGroupsPages and returns all IAM groups
-func (svc *IamSvc) Groups() ([]*iam.Group, error) {
+func (svc *IamSvc) Groups(ctx context.Context) ([]*iam.Group, error) {
 	var results []*iam.Group
-	err := svc.Client.ListGroupsPages(&iam.ListGroupsInput{},
+	err := svc.Client.ListGroupsPagesWithContext(ctx, &iam.ListGroupsInput{},
 		func(page *iam.ListGroupsOutput, lastPage bool) bool {
 			results = append(results, page.Groups...)
 			return !lastPage
@@ -39,9 +41,9 @@ func (svc *IamSvc) Groups() ([]*iam.Group, error) {
 }
 
 // Policies ... pages through ListPoliciesPages and returns all IAM policies
-func (svc *IamSvc) Policies() ([]*iam.Policy, error) {
+func (svc *IamSvc) Policies(ctx context.Context) ([]*iam.Policy, error) {
 	var results []*iam.Policy
-	err := svc.Client.ListPoliciesPages(&iam.ListPoliciesInput{},
+	err := svc.Client.ListPoliciesPagesWithContext(ctx, &iam.ListPoliciesInput{},
 		func(page *iam.ListPoliciesOutput, lastPage bool) bool {
 			results = append(results, page.Policies...)
 			return !lastPage
@@ -53,9 +55,9 @@ func (svc *IamSvc) Policies() ([]*iam.Policy, error) {
 }
 
 // Users ... pages through ListUsersPages and returns all IAM users
-func (svc *IamSvc) Users() ([]*iam.User, error) {
+func (svc *IamSvc) Users(ctx context.Context) ([]*iam.User, error) {
 	var results []*iam.User
-	err := svc.Client.ListUsersPages(&iam.ListUsersInput{},
+	err := svc.Client.ListUsersPagesWithContext(ctx, &iam.ListUsersInput{},
 		func(page *iam.ListUsersOutput, lastPage bool) bool {
 			results = append(results, page.Users...)
 			return !lastPage
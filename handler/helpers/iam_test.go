@@ -1,9 +1,12 @@
 package helpers
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 )
@@ -12,22 +15,22 @@ type mockIamClient struct {
 	iamiface.IAMAPI
 }
 
-func (m *mockIamClient) ListRolesPages(in *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool) error {
+func (m *mockIamClient) ListRolesPagesWithContext(ctx aws.Context, in *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool, opts ...request.Option) error {
 	fn(&iam.ListRolesOutput{Roles: []*iam.Role{{}}}, true)
 	return nil
 }
 
-func (m *mockIamClient) ListGroupsPages(in *iam.ListGroupsInput, fn func(*iam.ListGroupsOutput, bool) bool) error {
+func (m *mockIamClient) ListGroupsPagesWithContext(ctx aws.Context, in *iam.ListGroupsInput, fn func(*iam.ListGroupsOutput, bool) bool, opts ...request.Option) error {
 	fn(&iam.ListGroupsOutput{Groups: []*iam.Group{{}}}, true)
 	return nil
 }
 
-func (m *mockIamClient) ListPoliciesPages(in *iam.ListPoliciesInput, fn func(*iam.ListPoliciesOutput, bool) bool) error {
+func (m *mockIamClient) ListPoliciesPagesWithContext(ctx aws.Context, in *iam.ListPoliciesInput, fn func(*iam.ListPoliciesOutput, bool) bool, opts ...request.Option) error {
 	fn(&iam.ListPoliciesOutput{Policies: []*iam.Policy{{}}}, true)
 	return nil
 }
 
-func (m *mockIamClient) ListUsersPages(in *iam.ListUsersInput, fn func(*iam.ListUsersOutput, bool) bool) error {
+func (m *mockIamClient) ListUsersPagesWithContext(ctx aws.Context, in *iam.ListUsersInput, fn func(*iam.ListUsersOutput, bool) bool, opts ...request.Option) error {
 	fn(&iam.ListUsersOutput{Users: []*iam.User{{}}}, true)
 	return nil
 }
@@ -36,7 +39,7 @@ func (m *mockIamClient) ListUsersPages(in *iam.ListUsersInput, fn func(*iam.List
 func TestRoles(t *testing.T) {
 	svc := IamSvc{Client: &mockIamClient{}}
 	expected := []*iam.Role{{}}
-	roles, err := svc.Roles()
+	roles, err := svc.Roles(context.Background())
 	if err != nil {
 		t.Fatalf("Roles() failed: %v", err)
 	}
@@ -53,7 +56,7 @@ func TestRoles(t *testing.T) {
 func TestGroups(t *testing.T) {
 	svc := IamSvc{Client: &mockIamClient{}}
 	expected := []*iam.Group{{}}
-	groups, err := svc.Groups()
+	groups, err := svc.Groups(context.Background())
 	if err != nil {
 		t.Fatalf("Groups() failed: %v", err)
 	}
@@ -70,7 +73,7 @@ func TestGroups(t *testing.T) {
 func TestPolicies(t *testing.T) {
 	svc := IamSvc{Client: &mockIamClient{}}
 	expected := []*iam.Policy{{}}
-	policies, err := svc.Policies()
+	policies, err := svc.Policies(context.Background())
 	if err != nil {
 		t.Fatalf("Policies() failed: %v", err)
 	}
@@ -87,7 +90,7 @@ func TestPolicies(t *testing.T) {
 func TestUsers(t *testing.T) {
 	svc := IamSvc{Client: &mockIamClient{}}
 	expected := []*iam.User{{}}
-	users, err := svc.Users()
+	users, err := svc.Users(context.Background())
 	if err != nil {
 		t.Fatalf("Users() failed: %v", err)
 	}
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"context"
 	"errors"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -26,9 +27,9 @@ func NewRDSSvc(cfg client.ConfigProvider, cred *credentials.Credentials) (*RDSSv
 }
 
 // DBInstances ... pages through DescribeDBInstancesPages to get list of DBInstances
-func (svc RDSSvc) DBInstances() ([]*rds.DBInstance, error) {
+func (svc RDSSvc) DBInstances(ctx context.Context) ([]*rds.DBInstance, error) {
 	var results []*rds.DBInstance
-	err := svc.Client.DescribeDBInstancesPages(&rds.DescribeDBInstancesInput{},
+	err := svc.Client.DescribeDBInstancesPagesWithContext(ctx, &rds.DescribeDBInstancesInput{},
 		func(page *rds.DescribeDBInstancesOutput, lastPage bool) bool {
 			results = append(results, page.DBInstances...)
 			return !lastPage
@@ -40,9 +41,9 @@ func (svc RDSSvc) DBInstances() ([]*rds.DBInstance, error) {
 }
 
 // DBSnapshots ... pages through DescribeDBSnapshotsPages to get list of DBSnapshots
-func (svc RDSSvc) DBSnapshots() ([]*rds.DBSnapshot, error) {
+func (svc RDSSvc) DBSnapshots(ctx context.Context) ([]*rds.DBSnapshot, error) {
 	var results []*rds.DBSnapshot
-	err := svc.Client.DescribeDBSnapshotsPages(&rds.DescribeDBSnapshotsInput{},
+	err := svc.Client.DescribeDBSnapshotsPagesWithContext(ctx, &rds.DescribeDBSnapshotsInput{},
 		func(page *rds.DescribeDBSnapshotsOutput, lastPage bool) bool {
 			results = append(results, page.DBSnapshots...)
 			return !lastPage
@@ -1,10 +1,13 @@
 package helpers
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 )
@@ -42,7 +45,7 @@ type mockedRDS struct {
 	describeDBSnapshotsErr   error
 }
 
-func (m mockedRDS) DescribeDBInstancesPages(inp *rds.DescribeDBInstancesInput, f func(*rds.DescribeDBInstancesOutput, bool) bool) error {
+func (m mockedRDS) DescribeDBInstancesPagesWithContext(ctx aws.Context, inp *rds.DescribeDBInstancesInput, f func(*rds.DescribeDBInstancesOutput, bool) bool, opts ...request.Option) error {
 	m.Called("DescribeDBInstancesPages")
 	for i, p := range m.describeDBInstancesPages {
 		f(p, (i == (len(m.describeDBInstancesPages) - 1)))
@@ -50,7 +53,7 @@ func (m mockedRDS) DescribeDBInstancesPages(inp *rds.DescribeDBInstancesInput, f
 	return m.describeDBInstancesErr
 }
 
-func (m mockedRDS) DescribeDBSnapshotsPages(inp *rds.DescribeDBSnapshotsInput, f func(*rds.DescribeDBSnapshotsOutput, bool) bool) error {
+func (m mockedRDS) DescribeDBSnapshotsPagesWithContext(ctx aws.Context, inp *rds.DescribeDBSnapshotsInput, f func(*rds.DescribeDBSnapshotsOutput, bool) bool, opts ...request.Option) error {
 	m.Called("DescribeDBSnapshotsPages")
 	for i, p := range m.describeDBSnapshotsPages {
 		f(p, (i == (len(m.describeDBSnapshotsPages) - 1)))
@@ -140,7 +143,7 @@ func TestRDSSvc_DBInstances(t *testing.T) {
 			svc := RDSSvc{
 				Client: &m,
 			}
-			got, err := svc.DBInstances()
+			got, err := svc.DBInstances(context.Background())
 			if (err != nil) != tc.wantErr {
 				t.Errorf("RDSSvc.DBInstances() error = %v, wantErr %v", err, tc.wantErr)
 				return
@@ -241,7 +244,7 @@ func TestRDSSvc_DBSnapshots(t *testing.T) {
 			svc := RDSSvc{
 				Client: &m,
 			}
-			got, err := svc.DBSnapshots()
+			got, err := svc.DBSnapshots(context.Background())
 			if (err != nil) != tc.wantErr {
 				t.Errorf("RDSSvc.DBSnapshots() error = %v, wantErr %v", err, tc.wantErr)
 				return
@@ -0,0 +1,175 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// throttleErrors ... AWS error codes returned when a request was throttled
+var throttleErrors = map[string]interface{}{
+	"Throttling":                             nil,
+	"ThrottlingException":                    nil,
+	"ThrottledException":                     nil,
+	"RequestThrottled":                       nil,
+	"RequestThrottledException":              nil,
+	"RequestLimitExceeded":                   nil,
+	"TooManyRequestsException":               nil,
+	"ProvisionedThroughputExceededException": nil,
+	"SlowDown":                               nil,
+}
+
+// IsThrottle ... returns true if err, or any error it wraps, is an awserr.Error
+// with a throttling error code
+func IsThrottle(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		_, ok := throttleErrors[awsErr.Code()]
+		return ok
+	}
+	return false
+}
+
+// Retry ... configures the jittered exponential backoff of the requests made with a session
+// returned by Session
+type Retry struct {
+	Attempts  int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// DefaultRetry ... returns the Retry used by New
+func DefaultRetry() Retry {
+	return Retry{Attempts: 5, BaseDelay: 500 * time.Millisecond, MaxDelay: 20 * time.Second}
+}
+
+// attempts ... returns the number of attempts made for a request, at least one
+func (r Retry) attempts() int {
+	if r.Attempts < 1 {
+		return 1
+	}
+	return r.Attempts
+}
+
+// delay ... returns a random duration between zero and the exponential backoff for 'attempt'
+func (r Retry) delay(attempt int) time.Duration {
+	d := r.BaseDelay << uint(attempt)
+	if d <= 0 || d > r.MaxDelay {
+		d = r.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d))) // nolint: gosec
+}
+
+// RetryError ... returned by Do when every attempt of a request was throttled
+type RetryError struct {
+	Service  string
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s: gave up after %d attempts -> %v", e.Service, e.Attempts, e.Err)
+}
+
+// Unwrap ... returns the error from the last attempt
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Session ... returns a copy of 'sess' for the requests of the Unit provided. Every attempt
+// of a request, including retries, waits on the Unit's Limiter first. Throttling errors slow
+// down the Limiter and only the throttled request is retried, with jittered exponential
+// backoff, so a paginated call does not start over. The retryer replaces the SDK's default
+// retryer, so requests are attempted at most Retry.Attempts times
+func (s *Scheduler) Session(sess *session.Session, u Unit) *session.Session {
+	l := s.Limiter(u)
+	c := sess.Copy(&aws.Config{Retryer: retryer{Retry: s.Retry}})
+	c.Handlers.Sign.PushFrontNamed(request.NamedHandler{
+		Name: "scheduler.Limiter",
+		Fn: func(r *request.Request) {
+			if err := l.Wait(r.Context()); err != nil {
+				r.Error = awserr.New(request.CanceledErrorCode, "request context canceled", err)
+			}
+		},
+	})
+	c.Handlers.Retry.PushBackNamed(request.NamedHandler{
+		Name: "scheduler.Throttled",
+		Fn: func(r *request.Request) {
+			if IsThrottle(r.Error) {
+				l.Throttled()
+			}
+		},
+	})
+	c.Handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: "scheduler.Succeeded",
+		Fn: func(r *request.Request) {
+			if r.Error == nil {
+				l.Succeeded()
+			}
+		},
+	})
+	return c
+}
+
+// retryer ... a request.Retryer retrying throttled and retryable requests with the backoff of Retry
+type retryer struct {
+	Retry
+}
+
+// MaxRetries ... returns the number of retries after the first attempt
+func (r retryer) MaxRetries() int {
+	return r.attempts() - 1
+}
+
+// RetryRules ... returns the jittered delay before the next attempt of the request
+func (r retryer) RetryRules(req *request.Request) time.Duration {
+	return r.delay(req.RetryCount)
+}
+
+// ShouldRetry ... returns true if the request was throttled or failed with a retryable error
+func (r retryer) ShouldRetry(req *request.Request) bool {
+	if req.Retryable != nil {
+		return *req.Retryable
+	}
+	return IsThrottle(req.Error) || req.IsErrorThrottle() || req.IsErrorRetryable()
+}
+
+// Do ... calls 'fn' once, unless 'ctx' is done, in which case ctx.Err() is returned. The
+// requests 'fn' makes with a session returned by Session are rate limited and retried by
+// the scheduler, so a throttling error returned by 'fn' outlasted every attempt of a request
+// and is wrapped in a *RetryError, any other error is returned as is
+func (s *Scheduler) Do(ctx context.Context, u Unit, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := fn()
+	if IsThrottle(err) {
+		return &RetryError{Service: u.Service, Attempts: s.Retry.attempts(), Err: err}
+	}
+	return err
+}
+
+// sleepContext ... pauses for 'd' or until 'ctx' is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"gotest.tools/v3/assert"
+)
+
+func TestIsThrottle(t *testing.T) {
+	tt := map[string]struct {
+		in       error
+		expected bool
+	}{
+		"nil":                  {},
+		"not an awserr":        {in: errors.New("test")},
+		"access denied":        {in: awserr.New("AccessDenied", "test", nil)},
+		"Throttling":           {in: awserr.New("Throttling", "test", nil), expected: true},
+		"ThrottlingException":  {in: awserr.New("ThrottlingException", "test", nil), expected: true},
+		"RequestLimitExceeded": {in: awserr.New("RequestLimitExceeded", "test", nil), expected: true},
+		"wrapped":              {in: fmt.Errorf("wrapped: %w", awserr.New("Throttling", "test", nil)), expected: true},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsThrottle(tc.in))
+		})
+	}
+}
+
+// func (s *Scheduler) Do(ctx context.Context, u Unit, fn func() error) error
+func TestDo(t *testing.T) {
+	throttled := awserr.New("RequestLimitExceeded", "test", nil)
+	denied := awserr.New("AccessDenied", "test", nil)
+	tt := map[string]struct {
+		err              error
+		expectedCalls    int
+		expectedErr      error
+		expectedAttempts int
+		canceled         bool
+	}{
+		"success":           {expectedCalls: 1},
+		"other error":       {err: denied, expectedCalls: 1, expectedErr: denied},
+		"exhausted retries": {err: throttled, expectedCalls: 1, expectedAttempts: 3},
+		"canceled":          {canceled: true, expectedErr: context.Canceled},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			s := New(1, nil)
+			s.Retry = Retry{Attempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.canceled {
+				cancel()
+			}
+			calls := 0
+			err := s.Do(ctx, Unit{Service: "ec2"}, func() error {
+				calls++
+				return tc.err
+			})
+			assert.Equal(t, tc.expectedCalls, calls)
+			if tc.expectedAttempts > 0 {
+				var retryErr *RetryError
+				assert.Assert(t, errors.As(err, &retryErr))
+				assert.Equal(t, "ec2", retryErr.Service)
+				assert.Equal(t, tc.expectedAttempts, retryErr.Attempts)
+				assert.Assert(t, IsThrottle(err))
+				return
+			}
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+// func (s *Scheduler) Session(sess *session.Session, u Unit) *session.Session
+func TestSession(t *testing.T) {
+	// serves two pages of accounts, throttling the first 'throttles' requests for the second page
+	newServer := func(throttles int) (*httptest.Server, *int32) {
+		var requests int32
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+			if !strings.Contains(string(body), "page2") {
+				fmt.Fprint(w, `{"Accounts":[{"Id":"111111111111"}],"NextToken":"page2"}`)
+				return
+			}
+			if throttles > 0 {
+				throttles--
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"__type":"TooManyRequestsException","Message":"slow down"}`)
+				return
+			}
+			fmt.Fprint(w, `{"Accounts":[{"Id":"222222222222"}]}`)
+		})), &requests
+	}
+	tt := map[string]struct {
+		throttles        int
+		expectedRequests int32
+		expectedAccounts int
+		expectedErr      bool
+	}{
+		"not throttled":     {expectedRequests: 2, expectedAccounts: 2},
+		"throttled twice":   {throttles: 2, expectedRequests: 4, expectedAccounts: 2},
+		"exhausted retries": {throttles: 10, expectedRequests: 4, expectedAccounts: 1, expectedErr: true},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			srv, requests := newServer(tc.throttles)
+			defer srv.Close()
+			sess, err := session.NewSession(&aws.Config{
+				Endpoint:    aws.String(srv.URL),
+				Region:      aws.String("us-east-1"),
+				Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+			})
+			assert.NilError(t, err)
+
+			s := New(1, map[string]float64{"organizations": 1000})
+			s.Retry = Retry{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+			u := Unit{Account: "111111111111", Region: "us-east-1", Service: "organizations"}
+			svc := organizations.New(s.Session(sess, u))
+			accounts := 0
+			err = s.Do(context.Background(), u, func() error {
+				return svc.ListAccountsPagesWithContext(context.Background(), &organizations.ListAccountsInput{},
+					func(page *organizations.ListAccountsOutput, lastPage bool) bool {
+						accounts += len(page.Accounts)
+						return !lastPage
+					})
+			})
+			// only the throttled page is requested again, never the first page
+			assert.Equal(t, tc.expectedRequests, atomic.LoadInt32(requests))
+			assert.Equal(t, tc.expectedAccounts, accounts)
+			if tc.expectedErr {
+				var retryErr *RetryError
+				assert.Assert(t, errors.As(err, &retryErr))
+				assert.Equal(t, 3, retryErr.Attempts)
+				assert.Assert(t, s.Limiter(u).rate < 1000)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+// func (l *Limiter) Throttled() and func (l *Limiter) Succeeded()
+func TestAdaptiveRate(t *testing.T) {
+	l := NewLimiter(10)
+	l.Throttled()
+	assert.Equal(t, 5.0, l.rate)
+	for i := 0; i < 10; i++ {
+		l.Throttled()
+	}
+	assert.Equal(t, 1.0, l.rate)
+	l.Succeeded()
+	assert.Equal(t, 2.0, l.rate)
+	for i := 0; i < 20; i++ {
+		l.Succeeded()
+	}
+	assert.Equal(t, 10.0, l.rate)
+
+	unlimited := NewLimiter(0)
+	unlimited.Throttled()
+	assert.Equal(t, 0.0, unlimited.rate)
+}
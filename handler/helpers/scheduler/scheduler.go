@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Unit ... identifies a single piece of work, one service queried in one account and region
+type Unit struct {
+	Account string
+	Region  string
+	Service string
+}
+
+func (u Unit) String() string {
+	return fmt.Sprintf("%s/%s/%s", u.Account, u.Region, u.Service)
+}
+
+// Scheduler ... runs Units on a bounded number of workers, requests made with a session
+// returned by Session wait on the Unit's Limiter and are retried when throttled
+type Scheduler struct {
+	Retry    Retry
+	sem      chan struct{}
+	limits   map[string]float64
+	mu       sync.Mutex
+	limiters map[Unit]*Limiter
+}
+
+// New ... returns a *Scheduler that runs at most 'workers' Units concurrently,
+// 'limits' holds the number of calls per second allowed for each service,
+// services without a limit are not rate limited
+func New(workers int, limits map[string]float64) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Scheduler{
+		Retry:    DefaultRetry(),
+		sem:      make(chan struct{}, workers),
+		limits:   limits,
+		limiters: make(map[Unit]*Limiter),
+	}
+}
+
+// Limiter ... returns the *Limiter for the account, region and service of the Unit provided,
+// creating it with the rate limit of the service if necessary. AWS throttles every account
+// and region separately, so throttling in one of them does not slow down the others
+func (s *Scheduler) Limiter(u Unit) *Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l, ok := s.limiters[u]; ok {
+		return l
+	}
+	l := NewLimiter(s.limits[u.Service])
+	s.limiters[u] = l
+	return l
+}
+
+// Go ... blocks until a worker is free, then calls 'fn' in a new go routine. If 'ctx'
+// is done before a worker is free, 'fn' is not called and ctx.Err() is returned
+func (s *Scheduler) Go(ctx context.Context, u Unit, fn func()) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	go func() {
+		defer func() { <-s.sem }()
+		fn()
+	}()
+	return nil
+}
+
+// ParseLimits ... parses a slice of service=rate pairs (e.g. ec2=20) into a map
+func ParseLimits(pairs []string) (map[string]float64, error) {
+	limits := make(map[string]float64)
+	for _, p := range pairs {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid rate limit %q, expected service=rate", p)
+		}
+		rate, err := strconv.ParseFloat(kv[1], 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid rate for service %q: %q", kv[0], kv[1])
+		}
+		limits[kv[0]] = rate
+	}
+	return limits, nil
+}
+
+// Limiter ... a token bucket that refills at 'rate' tokens per second,
+// holding at most one second worth of tokens. The rate adapts to throttling,
+// it is halved by Throttled and recovers towards the configured rate by Succeeded
+type Limiter struct {
+	mu     sync.Mutex
+	max    float64
+	rate   float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewLimiter ... returns a *Limiter allowing 'rate' events per second,
+// a rate of zero or less disables limiting
+func NewLimiter(rate float64) *Limiter {
+	return &Limiter{max: rate, rate: rate, tokens: burst(rate), now: time.Now}
+}
+
+// Throttled ... halves the current rate, but never below one tenth of the configured rate
+func (l *Limiter) Throttled() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.max <= 0 {
+		return
+	}
+	l.rate /= 2
+	if floor := l.max / 10; l.rate < floor {
+		l.rate = floor
+	}
+}
+
+// Succeeded ... raises the current rate by one tenth of the configured rate, up to the configured rate
+func (l *Limiter) Succeeded() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.max <= 0 || l.rate >= l.max {
+		return
+	}
+	l.rate += l.max / 10
+	if l.rate > l.max {
+		l.rate = l.max
+	}
+}
+
+// Wait ... blocks until a token is available or 'ctx' is done
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		d := l.reserve()
+		if d <= 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve ... takes a token if one is available and returns zero,
+// otherwise returns how long to wait before trying again
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate <= 0 {
+		return 0
+	}
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if limit := burst(l.rate); l.tokens > limit {
+			l.tokens = limit
+		}
+	}
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// burst ... the bucket holds one second worth of tokens, but never less than one
+func burst(rate float64) float64 {
+	if rate < 1 {
+		return 1
+	}
+	return rate
+}
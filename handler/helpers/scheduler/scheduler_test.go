@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+// func (s *Scheduler) Go(u Unit, fn func())
+func TestGo(t *testing.T) {
+	workers := 3
+	s := New(workers, nil)
+
+	var running, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		err := s.Go(context.Background(), Unit{Account: "a", Region: "us-east-1", Service: "ec2"}, func() {
+			defer wg.Done()
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		})
+		assert.NilError(t, err)
+	}
+	wg.Wait()
+	assert.Assert(t, peak <= int32(workers), "expected at most %d concurrent units, got %d", workers, peak)
+
+	// once the context is done, no more units are started
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	err := s.Go(ctx, Unit{}, func() { called = true })
+	assert.Equal(t, context.Canceled, err)
+	assert.Assert(t, !called)
+
+	// waiting for a busy worker is abandoned when the context is done
+	busy := New(1, nil)
+	release := make(chan struct{})
+	assert.NilError(t, busy.Go(context.Background(), Unit{}, func() { <-release }))
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = busy.Go(ctx, Unit{}, func() { called = true })
+	close(release)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Assert(t, !called)
+}
+
+// func (s *Scheduler) Limiter(u Unit) *Limiter
+func TestLimiter(t *testing.T) {
+	s := New(1, map[string]float64{"ec2": 5})
+	east := Unit{Account: "a", Region: "us-east-1", Service: "ec2"}
+	assert.Equal(t, s.Limiter(east), s.Limiter(east))
+	assert.Equal(t, 5.0, s.Limiter(east).rate)
+	assert.Equal(t, 0.0, s.Limiter(Unit{Account: "a", Region: "us-east-1", Service: "iam"}).rate)
+
+	// every account and region is limited separately
+	s.Limiter(east).Throttled()
+	for _, u := range []Unit{{Account: "a", Region: "us-west-1", Service: "ec2"}, {Account: "b", Region: "us-east-1", Service: "ec2"}} {
+		assert.Assert(t, s.Limiter(east) != s.Limiter(u))
+		assert.Equal(t, 5.0, s.Limiter(u).rate)
+	}
+}
+
+// func (l *Limiter) reserve() time.Duration
+func TestReserve(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(2)
+	l.now = func() time.Time { return now }
+
+	// the bucket starts full with one second of tokens
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, 500*time.Millisecond, l.reserve())
+
+	// refills at 'rate' tokens per second
+	now = now.Add(500 * time.Millisecond)
+	assert.Equal(t, time.Duration(0), l.reserve())
+
+	// never holds more than one second of tokens
+	now = now.Add(time.Minute)
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Assert(t, l.reserve() > 0)
+
+	unlimited := NewLimiter(0)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, time.Duration(0), unlimited.reserve())
+	}
+}
+
+// func ParseLimits(pairs []string) (map[string]float64, error)
+func TestParseLimits(t *testing.T) {
+	tt := map[string]struct {
+		in          []string
+		expected    map[string]float64
+		expectedErr string
+	}{
+		"empty":   {expected: map[string]float64{}},
+		"one":     {in: []string{"ec2=20"}, expected: map[string]float64{"ec2": 20}},
+		"two":     {in: []string{"ec2=20", " iam=2.5"}, expected: map[string]float64{"ec2": 20, "iam": 2.5}},
+		"no rate": {in: []string{"ec2"}, expectedErr: `invalid rate limit "ec2"`},
+		"bad":     {in: []string{"ec2=fast"}, expectedErr: `invalid rate for service "ec2"`},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			actual, err := ParseLimits(tc.in)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.expected, actual)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"context"
 	"errors"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -26,9 +27,9 @@ func NewSecretsManagerSvc(cfg client.ConfigProvider, cred *credentials.Credentia
 }
 
 // Secrets ... pages through ListSecretsPages to get list of Secrets
-func (svc SecretsManagerSvc) Secrets() ([]*secretsmanager.SecretListEntry, error) {
+func (svc SecretsManagerSvc) Secrets(ctx context.Context) ([]*secretsmanager.SecretListEntry, error) {
 	var results []*secretsmanager.SecretListEntry
-	err := svc.Client.ListSecretsPages(&secretsmanager.ListSecretsInput{},
+	err := svc.Client.ListSecretsPagesWithContext(ctx, &secretsmanager.ListSecretsInput{},
 		func(page *secretsmanager.ListSecretsOutput, lastPage bool) bool {
 			results = append(results, page.SecretList...)
 			return !lastPage
@@ -1,11 +1,13 @@
 package helpers
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 )
@@ -18,7 +20,7 @@ type mockedSecretsManager struct {
 	describeSecretsErr   error
 }
 
-func (m mockedSecretsManager) ListSecretsPages(inp *secretsmanager.ListSecretsInput, f func(*secretsmanager.ListSecretsOutput, bool) bool) error {
+func (m mockedSecretsManager) ListSecretsPagesWithContext(ctx aws.Context, inp *secretsmanager.ListSecretsInput, f func(*secretsmanager.ListSecretsOutput, bool) bool, opts ...request.Option) error {
 	m.Called("DescribeSecretsPages")
 	for i, p := range m.describeSecretsPages {
 		f(p, (i == (len(m.describeSecretsPages) - 1)))
@@ -85,7 +87,7 @@ func TestSecretsManagerSvc_Secrets(t *testing.T) {
 			svc := SecretsManagerSvc{
 				Client: &m,
 			}
-			got, err := svc.Secrets()
+			got, err := svc.Secrets(context.Background())
 			if (err != nil) != tc.wantErr {
 				t.Errorf("EC2Svc.Secrets() error = %v, wantErr %v", err, tc.wantErr)
 				return
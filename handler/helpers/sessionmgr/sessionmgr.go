@@ -1,43 +1,150 @@
 package sessionmgr
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	// RegionsAll ... discovers every region of the partition, including opt-in regions
+	RegionsAll = "all"
+	// RegionsEnabled ... discovers the regions enabled for the account of the default session
+	RegionsEnabled = "enabled"
 )
 
-// SessionMgr ... holds one session per region provided to New()
+// SessionMgr ... holds one session per region provided to New(), or per region discovered
+// when the regions provided are RegionsAll or RegionsEnabled
 type SessionMgr struct {
 	defaultRegion string
 	regions       []string
+	include       []string
+	exclude       []string
 	fn            Sessioner
+	describe      RegionDescriber
 	sessions      []*session.Session
 }
 
 // Sessioner returns a new *session.Session and an error
 type Sessioner func(cfgs ...*aws.Config) (*session.Session, error)
 
+// RegionDescriber returns the regions of the partition of 'sess', every region if 'all' is true,
+// otherwise only the regions enabled for the account, along with their opt-in status
+type RegionDescriber func(ctx context.Context, sess *session.Session, all bool) ([]*ec2.Region, error)
+
 // New ... returns a *SessionMgr
 func New(defaultRegion string, regions []string) *SessionMgr {
-	return &SessionMgr{defaultRegion: defaultRegion, regions: regions, fn: session.NewSession}
+	return &SessionMgr{defaultRegion: defaultRegion, regions: regions, fn: session.NewSession, describe: describeRegions}
+}
+
+// IsDiscovery ... returns true if 'regions' asks for the regions to be discovered
+func IsDiscovery(regions []string) bool {
+	return len(regions) == 1 && (regions[0] == RegionsAll || regions[0] == RegionsEnabled)
+}
+
+// ValidatePatterns ... returns an error if any of 'patterns' is not a valid region pattern
+func ValidatePatterns(patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid region pattern %q: %v", p, err)
+		}
+	}
+	return nil
 }
 
-// Init ... creates one session per region provided to New()
-func (mgr *SessionMgr) Init() error {
-	for _, r := range mgr.regions {
+// Filter ... only creates sessions for regions matching one of the 'include' patterns,
+// if any are provided, and none of the 'exclude' patterns, e.g. us-* or ap-southeast-?
+func (mgr *SessionMgr) Filter(include, exclude []string) {
+	mgr.include = include
+	mgr.exclude = exclude
+}
+
+// Init ... creates one session per region provided to New(), or per region
+// discovered using the default session, that passes the include and exclude filters
+func (mgr *SessionMgr) Init(ctx context.Context) error {
+	regions := mgr.regions
+	if IsDiscovery(regions) {
+		var err error
+		regions, err = mgr.discover(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range regions {
+		if !mgr.matches(r) {
+			continue
+		}
 		sess, err := mgr.fn(&aws.Config{Region: aws.String(r)})
 		if err != nil {
 			return err
 		}
 		mgr.sessions = append(mgr.sessions, sess)
 	}
+	if len(mgr.sessions) == 0 {
+		return errors.New("no regions left to inventory after applying the region filters")
+	}
 	return nil
 }
 
+// discover ... returns the names of the regions described using the default session
+func (mgr *SessionMgr) discover(ctx context.Context) ([]string, error) {
+	sess, err := mgr.Default()
+	if err != nil {
+		return nil, err
+	}
+	described, err := mgr.describe(ctx, sess, mgr.regions[0] == RegionsAll)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover regions: %v", err)
+	}
+	var regions []string
+	for _, r := range described {
+		regions = append(regions, aws.StringValue(r.RegionName))
+	}
+	return regions, nil
+}
+
+// matches ... returns true if 'region' passes the include and exclude filters
+func (mgr *SessionMgr) matches(region string) bool {
+	for _, p := range mgr.exclude {
+		if ok, _ := path.Match(p, region); ok {
+			return false
+		}
+	}
+	if len(mgr.include) == 0 {
+		return true
+	}
+	for _, p := range mgr.include {
+		if ok, _ := path.Match(p, region); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// describeRegions ... the default RegionDescriber, calls ec2:DescribeRegions
+func describeRegions(ctx context.Context, sess *session.Session, all bool) ([]*ec2.Region, error) {
+	out, err := ec2.New(sess).DescribeRegionsWithContext(ctx, &ec2.DescribeRegionsInput{AllRegions: aws.Bool(all)})
+	if err != nil {
+		return nil, err
+	}
+	return out.Regions, nil
+}
+
 // Sessioner ... sets the method to use for creating new sessions
 func (mgr *SessionMgr) Sessioner(sessioner Sessioner) {
 	mgr.fn = sessioner
 }
 
+// Describer ... sets the method to use for discovering regions
+func (mgr *SessionMgr) Describer(describer RegionDescriber) {
+	mgr.describe = describer
+}
+
 // All ... returns all sessions stored inside the *SessionMgr
 func (mgr *SessionMgr) All() []*session.Session {
 	return mgr.sessions
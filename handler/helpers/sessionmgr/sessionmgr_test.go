@@ -1,13 +1,16 @@
 package sessionmgr
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
 )
 
 var genericRegions = []string{"us-east-1", "us-west-1"}
@@ -41,7 +44,7 @@ func TestNew(t *testing.T) {
 		t.Run("test with 2 regions", func(t *testing.T) {
 			s := New(tc.defaultRegion, tc.regions)
 			s.Sessioner(mockNewSession)
-			err := s.Init()
+			err := s.Init(context.Background())
 			if err != nil {
 				t.Fatalf("Init() failed: %v", err)
 			}
@@ -59,7 +62,7 @@ func TestNew(t *testing.T) {
 func TestAll(t *testing.T) {
 	s := New(genericRegions[0], genericRegions)
 	s.Sessioner(mockNewSession)
-	err := s.Init()
+	err := s.Init(context.Background())
 	if err != nil {
 		t.Fatalf("Init() failed: %v", err)
 	}
@@ -73,7 +76,7 @@ func TestAll(t *testing.T) {
 func TestDefault(t *testing.T) {
 	s := New(genericRegions[0], genericRegions)
 	s.Sessioner(mockNewSession)
-	err := s.Init()
+	err := s.Init(context.Background())
 	if err != nil {
 		t.Fatalf("Init() failed: %v", err)
 	}
@@ -93,7 +96,7 @@ func TestDefault(t *testing.T) {
 func TestDefaultAltRegion(t *testing.T) {
 	s := New(genericRegions[0], genericRegions)
 	s.Sessioner(mockNewSession)
-	err := s.Init()
+	err := s.Init(context.Background())
 	if err != nil {
 		t.Fatalf("Init() failed: %v", err)
 	}
@@ -136,7 +139,7 @@ func TestRegion(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			s := New(tc.region, tc.regions)
 			s.Sessioner(mockNewSession)
-			err := s.Init()
+			err := s.Init(context.Background())
 			if err != nil {
 				t.Fatalf("Init() failed: %v", err)
 			}
@@ -163,3 +166,71 @@ func TestRegionErr(t *testing.T) {
 		t.Errorf("failure expected but err was nil")
 	}
 }
+
+// func (mgr *SessionMgr) Init(ctx context.Context) error
+func TestInitDiscovery(t *testing.T) {
+	described := func(ctx context.Context, sess *session.Session, all bool) ([]*ec2.Region, error) {
+		regions := []*ec2.Region{
+			{RegionName: aws.String("us-east-1"), OptInStatus: aws.String("opt-in-not-required")},
+			{RegionName: aws.String("us-west-2"), OptInStatus: aws.String("opt-in-not-required")},
+			{RegionName: aws.String("eu-west-1"), OptInStatus: aws.String("opt-in-not-required")},
+		}
+		if all {
+			regions = append(regions, &ec2.Region{RegionName: aws.String("af-south-1"), OptInStatus: aws.String("not-opted-in")})
+		}
+		return regions, nil
+	}
+	tests := []struct {
+		name     string
+		regions  []string
+		include  []string
+		exclude  []string
+		expected []string
+		err      bool
+	}{
+		{name: "static", regions: genericRegions, expected: genericRegions},
+		{name: "static excluded", regions: genericRegions, exclude: []string{"us-west-*"}, expected: []string{"us-east-1"}},
+		{name: "enabled", regions: []string{RegionsEnabled}, expected: []string{"us-east-1", "us-west-2", "eu-west-1"}},
+		{name: "all", regions: []string{RegionsAll}, expected: []string{"us-east-1", "us-west-2", "eu-west-1", "af-south-1"}},
+		{name: "included", regions: []string{RegionsAll}, include: []string{"us-*", "af-*"}, exclude: []string{"us-west-?"}, expected: []string{"us-east-1", "af-south-1"}},
+		{name: "none left", regions: []string{RegionsEnabled}, include: []string{"cn-*"}, err: true},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			s := New("us-east-1", tc.regions)
+			s.Sessioner(mockNewSession)
+			s.Describer(described)
+			s.Filter(tc.include, tc.exclude)
+			err := s.Init(context.Background())
+			if tc.err {
+				if err == nil {
+					t.Fatal("Init() should fail when no regions are left")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Init() failed: %v", err)
+			}
+			var actual []string
+			for _, sess := range s.All() {
+				actual = append(actual, aws.StringValue(sess.Config.Region))
+			}
+			if !reflect.DeepEqual(tc.expected, actual) {
+				t.Fatalf("Init() regions invalid, expected: %v, got: %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestInitDiscoveryErr(t *testing.T) {
+	s := New("us-east-1", []string{RegionsAll})
+	s.Sessioner(mockNewSession)
+	s.Describer(func(context.Context, *session.Session, bool) ([]*ec2.Region, error) {
+		return nil, errors.New("denied")
+	})
+	err := s.Init(context.Background())
+	if err == nil || err.Error() != "failed to discover regions: denied" {
+		t.Fatalf("Init() error invalid, expected: failed to discover regions: denied, got: %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"context"
+
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 )
@@ -18,9 +20,9 @@ type SnsTopic struct {
 }
 
 // Subscriptions ... pages through ListSubscriptionsPages to get list of Subscriptions
-func Subscriptions(svc snsiface.SNSAPI) ([]*sns.Subscription, error) {
+func Subscriptions(ctx context.Context, svc snsiface.SNSAPI) ([]*sns.Subscription, error) {
 	var results []*sns.Subscription
-	err := svc.ListSubscriptionsPages(&sns.ListSubscriptionsInput{},
+	err := svc.ListSubscriptionsPagesWithContext(ctx, &sns.ListSubscriptionsInput{},
 		func(page *sns.ListSubscriptionsOutput, lastPage bool) bool {
 			results = append(results, page.Subscriptions...)
 			return !lastPage
@@ -32,18 +34,18 @@ func Subscriptions(svc snsiface.SNSAPI) ([]*sns.Subscription, error) {
 }
 
 // Topics ... pages over ListTopics results and returns all Topics parameters
-func Topics(svc snsiface.SNSAPI) ([]*SnsTopic, error) {
-	topicList, err := listTopics(svc)
+func Topics(ctx context.Context, svc snsiface.SNSAPI) ([]*SnsTopic, error) {
+	topicList, err := listTopics(ctx, svc)
 	if err != nil {
 		return nil, err
 	}
-	return getTopicAttributes(svc, topicList)
+	return getTopicAttributes(ctx, svc, topicList)
 }
 
 // listTopics ... pages through ListTopicsPages to get list of TopicArns
-func listTopics(svc snsiface.SNSAPI) ([]*sns.Topic, error) {
+func listTopics(ctx context.Context, svc snsiface.SNSAPI) ([]*sns.Topic, error) {
 	var results []*sns.Topic
-	err := svc.ListTopicsPages(&sns.ListTopicsInput{},
+	err := svc.ListTopicsPagesWithContext(ctx, &sns.ListTopicsInput{},
 		func(page *sns.ListTopicsOutput, lastPage bool) bool {
 			results = append(results, page.Topics...)
 			return !lastPage
@@ -55,11 +57,11 @@ func listTopics(svc snsiface.SNSAPI) ([]*sns.Topic, error) {
 }
 
 // getTopicAttributes ... loops through list of Topic ARNs to get Topic attributes GetTopicAttributes())
-func getTopicAttributes(svc snsiface.SNSAPI, topicList []*sns.Topic) ([]*SnsTopic, error) {
+func getTopicAttributes(ctx context.Context, svc snsiface.SNSAPI, topicList []*sns.Topic) ([]*SnsTopic, error) {
 	var topics []*SnsTopic
 	for _, t := range topicList {
 		input := &sns.GetTopicAttributesInput{TopicArn: t.TopicArn}
-		result, err := svc.GetTopicAttributes(input)
+		result, err := svc.GetTopicAttributesWithContext(ctx, input)
 		if err != nil {
 			return nil, err
 		}
@@ -1,9 +1,12 @@
 package helpers
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 )
@@ -12,17 +15,17 @@ type mockSnsClient struct {
 	snsiface.SNSAPI
 }
 
-func (m mockSnsClient) ListSubscriptionsPages(in *sns.ListSubscriptionsInput, fn func(*sns.ListSubscriptionsOutput, bool) bool) error {
+func (m mockSnsClient) ListSubscriptionsPagesWithContext(ctx aws.Context, in *sns.ListSubscriptionsInput, fn func(*sns.ListSubscriptionsOutput, bool) bool, opts ...request.Option) error {
 	fn(&sns.ListSubscriptionsOutput{Subscriptions: []*sns.Subscription{{}}}, true)
 	return nil
 }
 
-func (m mockSnsClient) ListTopicsPages(in *sns.ListTopicsInput, fn func(*sns.ListTopicsOutput, bool) bool) error {
+func (m mockSnsClient) ListTopicsPagesWithContext(ctx aws.Context, in *sns.ListTopicsInput, fn func(*sns.ListTopicsOutput, bool) bool, opts ...request.Option) error {
 	fn(&sns.ListTopicsOutput{Topics: []*sns.Topic{{}}}, true)
 	return nil
 }
 
-func (m mockSnsClient) GetTopicAttributes(in *sns.GetTopicAttributesInput) (*sns.GetTopicAttributesOutput, error) {
+func (m mockSnsClient) GetTopicAttributesWithContext(ctx aws.Context, in *sns.GetTopicAttributesInput, opts ...request.Option) (*sns.GetTopicAttributesOutput, error) {
 	return &sns.GetTopicAttributesOutput{Attributes: map[string]*string{}}, nil
 }
 
@@ -30,7 +33,7 @@ func (m mockSnsClient) GetTopicAttributes(in *sns.GetTopicAttributesInput) (*sns
 func TestSubscriptions(t *testing.T) {
 	expected := []*sns.Subscription{{}}
 	svc := mockSnsClient{}
-	got, err := Subscriptions(svc)
+	got, err := Subscriptions(context.Background(), svc)
 	if err != nil {
 		t.Fatalf("Subscriptions() failed: %v", err)
 	}
@@ -47,7 +50,7 @@ func TestSubscriptions(t *testing.T) {
 func TestTopics(t *testing.T) {
 	expected := []*SnsTopic{{}}
 	svc := mockSnsClient{}
-	got, err := Topics(svc)
+	got, err := Topics(context.Background(), svc)
 	if err != nil {
 		t.Fatalf("Topics() failed: %v", err)
 	}
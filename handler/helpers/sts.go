@@ -0,0 +1,39 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+// StsSvc ... uses an SDK service iface to access SDK service client
+type StsSvc struct {
+	Client stsiface.STSAPI
+}
+
+// AccountAccess ... the result of the access check of an account, the ARN its credentials
+// resolved to, or the code and message of the error when the account is unreachable
+type AccountAccess struct {
+	AccountID   string
+	AccountName string
+	Reachable   bool
+	Arn         string
+	Code        string
+	Message     string
+}
+
+// Identity ... performs GetCallerIdentity and returns the ARN of the caller, or an error
+// if the credentials resolved to an account other than 'accountID'
+func (svc *StsSvc) Identity(ctx context.Context, accountID string) (string, error) {
+	result, err := svc.Client.GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+	if aws.StringValue(result.Account) != accountID {
+		return "", fmt.Errorf("credentials resolved to account %s, expected %s", aws.StringValue(result.Account), accountID)
+	}
+	return aws.StringValue(result.Arn), nil
+}
@@ -0,0 +1,42 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+type mockStsClient struct {
+	stsiface.STSAPI
+}
+
+func (m *mockStsClient) GetCallerIdentityWithContext(ctx aws.Context, in *sts.GetCallerIdentityInput, opts ...request.Option) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{
+		Account: aws.String("111111111111"),
+		Arn:     aws.String("arn:aws:sts::111111111111:assumed-role/tenantRole/session"),
+	}, nil
+}
+
+// func Identity(ctx context.Context, accountID string) (string, error)
+func TestIdentity(t *testing.T) {
+	svc := StsSvc{Client: &mockStsClient{}}
+	arn, err := svc.Identity(context.Background(), "111111111111")
+	if err != nil {
+		t.Fatalf("Identity() failed: %v", err)
+	}
+	if arn != "arn:aws:sts::111111111111:assumed-role/tenantRole/session" {
+		t.Fatalf("Identity() failed, expected the assumed role ARN, got: %s", arn)
+	}
+	_, err = svc.Identity(context.Background(), "222222222222")
+	if err == nil || err.Error() != "credentials resolved to account 111111111111, expected 222222222222" {
+		t.Fatalf("Identity() should fail for another account, got: %v", err)
+	}
+	sheet, err := TypeToSheet([]*AccountAccess{{}})
+	if err != nil || sheet != SheetAccountAccess {
+		t.Fatalf("TypeToSheet failed, expected: %s, got: %s, %v", SheetAccountAccess, sheet, err)
+	}
+}
@@ -0,0 +1,384 @@
+package inv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/caarlos0/env/v6"
+
+	"github.com/GSA/grace-inventory/handler/helpers"
+	"github.com/GSA/grace-inventory/handler/helpers/accounts"
+	"github.com/GSA/grace-inventory/handler/helpers/credmgr"
+	"github.com/GSA/grace-inventory/handler/helpers/scheduler"
+	"github.com/GSA/grace-inventory/handler/helpers/sessionmgr"
+	"github.com/GSA/grace-inventory/handler/spreadsheet"
+)
+
+// DefaultSheets ... the sheets added to the report when no sheets are configured
+var DefaultSheets = []string{
+	helpers.SheetAccounts,
+	helpers.SheetBuckets,
+	helpers.SheetGroups,
+	helpers.SheetImages,
+	helpers.SheetInstances,
+	helpers.SheetPolicies,
+	helpers.SheetRoles,
+	helpers.SheetSecurityGroups,
+	helpers.SheetSnapshots,
+	helpers.SheetSubnets,
+	helpers.SheetUsers,
+	helpers.SheetVolumes,
+	helpers.SheetVpcs,
+	helpers.SheetAddresses,
+	helpers.SheetKeyPairs,
+	helpers.SheetStacks,
+	helpers.SheetAlarms,
+	helpers.SheetConfigRules,
+	helpers.SheetLoadBalancers,
+	helpers.SheetVaults,
+	helpers.SheetKeys,
+	helpers.SheetDBInstances,
+	helpers.SheetDBSnapshots,
+	helpers.SheetSecrets,
+	helpers.SheetSubscriptions,
+	helpers.SheetTopics,
+	helpers.SheetParameters,
+	helpers.SheetRegions,
+	helpers.SheetAccountAccess,
+	helpers.SheetErrors,
+}
+
+// outputNameRegex ... matches output names that are safe to use as an object key or file name
+var outputNameRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// reportTimeLayout ... the time of the run, following the output name in the name of every report
+const reportTimeLayout = "2006-01-02-1504"
+
+// reportTimePattern ... matches the time of the run in the name of a report, see reportTimeLayout
+const reportTimePattern = `\d{4}-\d{2}-\d{2}-\d{4}`
+
+// accountIDRegex ... matches AWS account IDs
+var accountIDRegex = regexp.MustCompile(`^\d{12}$`)
+
+// Config ... holds the settings of an inventory, read from environment variables by LoadConfig
+type Config struct {
+	BucketID        string        `env:"s3_bucket"`
+	KmsKeyID        string        `env:"kms_key_id"`
+	Destination     string        `env:"destination"`
+	OutputDir       string        `env:"output_dir"`
+	Regions         []string      `env:"regions" envSeparator:","`
+	RegionInclude   []string      `env:"region_include" envSeparator:","`
+	RegionExclude   []string      `env:"region_exclude" envSeparator:","`
+	AccountsInfo    string        `env:"accounts_info" envDefault:"self"`
+	MasterAccountID string        `env:"master_account_id" envDefault:""`
+	OrgUnits        []string      `env:"organizational_units" envSeparator:","`
+	RecursiveOUs    bool          `env:"organizational_units_recursive" envDefault:"false"`
+	ExcludeOUs      []string      `env:"organizational_units_exclude" envSeparator:","`
+	AccountInclude  []string      `env:"account_include" envSeparator:","`
+	AccountExclude  []string      `env:"account_exclude" envSeparator:","`
+	MasterRoleName  string        `env:"master_role_name" envDefault:""`
+	TenantRoleName  string        `env:"tenant_role_name" envDefault:""`
+	AccountRoles    string        `env:"account_roles"`
+	RoleChain       []string      `env:"role_chain" envSeparator:","`
+	Sheets          []string      `env:"sheets" envSeparator:","`
+	MaxWorkers      int           `env:"max_workers" envDefault:"10"`
+	RateLimits      []string      `env:"rate_limits" envSeparator:","`
+	RetryAttempts   int           `env:"retry_attempts" envDefault:"5"`
+	PartialResults  bool          `env:"partial_results" envDefault:"false"`
+	DeadlineMargin  time.Duration `env:"deadline_margin" envDefault:"1m"`
+	OutputFormats   []string      `env:"output_formats" envDefault:"xlsx" envSeparator:","`
+	DriftReport     bool          `env:"drift_report" envDefault:"false"`
+	OutputName      string        `env:"output_name" envDefault:"grace_inventory"`
+	Profile         string        `env:"profile"`
+	Endpoint        string        `env:"endpoint"`
+
+	// scopeOverride ... a setting of the scope of the inventory overridden by Override while
+	// the output name is kept, empty if the scope is the configured one
+	scopeOverride string
+}
+
+// LoadConfig ... returns a *Config read from environment variables, unset
+// variables take their defaults. The *Config is not validated
+func LoadConfig() (*Config, error) {
+	cfg := &Config{}
+	err := env.Parse(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// DestinationType ... returns the destination reports are saved to, when it is
+// not set reports are saved to 'output_dir' if it is set, otherwise to S3
+func (cfg *Config) DestinationType() string {
+	d := strings.ToLower(strings.TrimSpace(cfg.Destination))
+	if d != "" {
+		return d
+	}
+	if cfg.OutputDir != "" {
+		return DestinationLocal
+	}
+	return DestinationS3
+}
+
+// Validate ... returns an error if a required setting is missing or a setting can not be
+// parsed. Which settings are required depends on the destination reports are saved to
+func (cfg *Config) Validate() error {
+	formats, err := parseFormats(cfg.OutputFormats)
+	if err != nil {
+		return err
+	}
+	err = cfg.validateDestination(formats)
+	if err != nil {
+		return err
+	}
+	if len(cfg.Regions) == 0 || cfg.Regions[0] == "" {
+		return errors.New(`required environment variable "regions" is not set`)
+	}
+	for _, r := range cfg.Regions {
+		if (r == sessionmgr.RegionsAll || r == sessionmgr.RegionsEnabled) && len(cfg.Regions) > 1 {
+			return fmt.Errorf("regions must be either %q, %q or a list of regions, got: %s",
+				sessionmgr.RegionsAll, sessionmgr.RegionsEnabled, strings.Join(cfg.Regions, ","))
+		}
+	}
+	err = sessionmgr.ValidatePatterns(append(append([]string(nil), cfg.RegionInclude...), cfg.RegionExclude...))
+	if err != nil {
+		return err
+	}
+	err = accounts.ValidateParents(cfg.OrgUnits, cfg.ExcludeOUs)
+	if err != nil {
+		return err
+	}
+	_, err = accounts.ParseFilter(cfg.AccountInclude, cfg.AccountExclude)
+	if err != nil {
+		return err
+	}
+	_, err = cfg.Roles()
+	if err != nil {
+		return err
+	}
+	err = credmgr.ValidateChain(cfg.RoleChain)
+	if err != nil {
+		return err
+	}
+	if cfg.OutputName != "" && !outputNameRegex.MatchString(cfg.OutputName) {
+		return fmt.Errorf("output_name may only contain letters, digits, '.', '-' and '_', got: %q", cfg.OutputName)
+	}
+	if cfg.DriftReport && cfg.scopeOverride != "" {
+		return fmt.Errorf("drift_report requires an output_name for inventories overriding %s, "+
+			"so they are not compared against the configured inventory", cfg.scopeOverride)
+	}
+	_, err = scheduler.ParseLimits(cfg.RateLimits)
+	if err != nil {
+		return err
+	}
+	registered := make(map[string]bool)
+	for _, name := range spreadsheet.Registered() {
+		registered[name] = true
+	}
+	for _, name := range cfg.SheetNames() {
+		if !registered[name] {
+			return fmt.Errorf("%s is not a registered Sheet Type", name)
+		}
+	}
+	return nil
+}
+
+// validateDestination ... returns an error if a setting required by the destination is missing
+func (cfg *Config) validateDestination(formats []string) error {
+	switch cfg.DestinationType() {
+	case DestinationS3:
+		if cfg.BucketID == "" {
+			return errors.New(`required environment variable "s3_bucket" is not set`)
+		}
+		if cfg.KmsKeyID == "" {
+			return errors.New(`required environment variable "kms_key_id" is not set`)
+		}
+	case DestinationLocal:
+		if cfg.OutputDir == "" {
+			return errors.New(`required environment variable "output_dir" is not set`)
+		}
+	case DestinationStdout:
+		// reports written to stdout can not be told apart, or read back to compare against
+		if len(formats) > 1 {
+			return fmt.Errorf("the %s destination supports one output format, got: %s", DestinationStdout, strings.Join(formats, ","))
+		}
+		if cfg.DriftReport {
+			return fmt.Errorf("drift_report is not supported by the %s destination", DestinationStdout)
+		}
+	default:
+		return fmt.Errorf("unsupported destination %q", cfg.Destination)
+	}
+	return nil
+}
+
+// AccountRole ... the assume role settings of an account in 'account_roles',
+// empty fields use the settings read from the account source or the defaults
+type AccountRole struct {
+	RoleName        string
+	ExternalID      string `json:"ExternalId"`
+	SessionName     string
+	DurationSeconds int64
+}
+
+// Roles ... returns the assume role settings by account ID parsed from 'account_roles',
+// a JSON object of account IDs to AccountRole, or an error naming the offending account
+func (cfg *Config) Roles() (map[string]credmgr.Role, error) {
+	if strings.TrimSpace(cfg.AccountRoles) == "" {
+		return nil, nil
+	}
+	var settings map[string]AccountRole
+	err := json.Unmarshal([]byte(cfg.AccountRoles), &settings)
+	if err != nil {
+		return nil, fmt.Errorf("account_roles is not a JSON object of account IDs to role settings: %v", err)
+	}
+	roles := make(map[string]credmgr.Role)
+	for id, s := range settings {
+		if !accountIDRegex.MatchString(id) {
+			return nil, fmt.Errorf("invalid account ID %q in account_roles", id)
+		}
+		roles[id] = credmgr.Role{
+			Name:        s.RoleName,
+			ExternalID:  s.ExternalID,
+			SessionName: s.SessionName,
+			Duration:    time.Duration(s.DurationSeconds) * time.Second,
+		}
+	}
+	err = credmgr.ValidateRoles(roles)
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// SheetNames ... returns the sheets to add to the report, DefaultSheets if none are configured.
+// Accounts is always the first sheet and Errors the last
+func (cfg *Config) SheetNames() []string {
+	var sheets []string
+	for _, s := range cfg.Sheets {
+		if s != "" {
+			sheets = append(sheets, s)
+		}
+	}
+	if len(sheets) == 0 {
+		return append([]string(nil), DefaultSheets...)
+	}
+
+	// prune any references to 'Account' after index zero
+	for i := 0; i < len(sheets); i++ {
+		if i > 0 && sheets[i] == helpers.SheetAccounts {
+			sheets = append(sheets[:i], sheets[i+1:]...)
+		}
+	}
+
+	// ensure the first element is always 'Accounts'
+	if sheets[0] != helpers.SheetAccounts {
+		sheets = append([]string{helpers.SheetAccounts}, sheets...)
+	}
+
+	// ensure the last element is always 'Errors'
+	for i := 0; i < len(sheets); i++ {
+		if sheets[i] == helpers.SheetErrors {
+			sheets = append(sheets[:i], sheets[i+1:]...)
+			i--
+		}
+	}
+	return append(sheets, helpers.SheetErrors)
+}
+
+// DefaultRegion ... returns the region the report is saved to and accounts are queried from,
+// the first of the regions, or the region of the Lambda function when regions are discovered
+func (cfg *Config) DefaultRegion() string {
+	if !sessionmgr.IsDiscovery(cfg.Regions) {
+		return cfg.Regions[0]
+	}
+	if r := os.Getenv("AWS_REGION"); r != "" {
+		return r
+	}
+	return "us-east-1"
+}
+
+// Filename ... returns the name of a report created at 't', the output name followed
+// by the time. Change reports compare against reports with the same output name
+func (cfg *Config) Filename(t time.Time) string {
+	return fmt.Sprintf("%s%s.xlsx", cfg.ReportPrefix(), t.Format(reportTimeLayout))
+}
+
+// ReportPrefix ... returns the start of the name of every report with the output name,
+// the output name followed by an underscore, e.g. grace_inventory_
+func (cfg *Config) ReportPrefix() string {
+	name := cfg.OutputName
+	if name == "" {
+		name = "grace_inventory"
+	}
+	return name + "_"
+}
+
+// Overrides ... settings that replace the configured settings for a single invocation,
+// e.g. the JSON event of the Lambda function. Empty fields are left unchanged
+type Overrides struct {
+	Sheets       []string `json:"sheets"`
+	Regions      []string `json:"regions"`
+	AccountsInfo string   `json:"accounts_info"`
+	OrgUnits     []string `json:"organizational_units"`
+	OutputName   string   `json:"output_name"`
+}
+
+// Override ... replaces the settings of 'cfg' with the non-empty fields of 'o'. An inventory whose
+// scope is overridden is not comparable to the configured one, so Validate requires it to have its
+// own output name when drift_report is set
+func (cfg *Config) Override(o *Overrides) {
+	if o == nil {
+		return
+	}
+	prefix := cfg.ReportPrefix()
+	var scope string
+	if len(o.Sheets) > 0 {
+		cfg.Sheets = o.Sheets
+		scope = "sheets"
+	}
+	if len(o.Regions) > 0 {
+		cfg.Regions = o.Regions
+		scope = "regions"
+	}
+	if o.AccountsInfo != "" {
+		cfg.AccountsInfo = o.AccountsInfo
+		scope = "accounts_info"
+	}
+	if len(o.OrgUnits) > 0 {
+		cfg.OrgUnits = o.OrgUnits
+		scope = "organizational_units"
+	}
+	if o.OutputName != "" {
+		cfg.OutputName = o.OutputName
+	}
+	if scope != "" && cfg.ReportPrefix() == prefix {
+		cfg.scopeOverride = scope
+	}
+}
+
+// newSessioner ... returns a sessionmgr.Sessioner creating sessions that use the
+// shared config 'profile' and send every request to 'endpoint', when they are set
+func newSessioner(profile, endpoint string) sessionmgr.Sessioner {
+	return func(cfgs ...*aws.Config) (*session.Session, error) {
+		base := &aws.Config{}
+		if endpoint != "" {
+			base.Endpoint = aws.String(endpoint)
+			base.S3ForcePathStyle = aws.Bool(true)
+		}
+		base.MergeIn(cfgs...)
+		opts := session.Options{Config: *base}
+		if profile != "" {
+			opts.Profile = profile
+			opts.SharedConfigState = session.SharedConfigEnable
+		}
+		return session.NewSessionWithOptions(opts)
+	}
+}
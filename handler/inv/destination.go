@@ -0,0 +1,186 @@
+package inv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+
+	"github.com/GSA/grace-inventory/handler/spreadsheet"
+)
+
+// Destination constants
+const (
+	DestinationS3     = "s3"
+	DestinationLocal  = "local"
+	DestinationStdout = "stdout"
+)
+
+// Destination ... saves the reports of a run, and reads the report of a previous run for the change report
+type Destination interface {
+	// Save ... saves the report 'name', reading its content from 'body'
+	Save(ctx context.Context, name, contentType string, body io.Reader, summary *Summary) error
+	// Previous ... returns the name and content of the most recent NDJSON report named
+	// 'prefix' followed by the time of its run, other than 'current'
+	Previous(ctx context.Context, prefix, current string) (string, io.ReadCloser, error)
+}
+
+// s3Destination ... saves reports to an S3 bucket, encrypted with a KMS key
+type s3Destination struct {
+	sess     *session.Session
+	bucketID string
+	kmsKeyID string
+}
+
+// Save ... streams 'body' into a multipart upload, only the parts being uploaded are held in
+// memory. The inventory-status metadata of the object is set to the status of the summary provided
+func (d *s3Destination) Save(ctx context.Context, name, contentType string, body io.Reader, summary *Summary) error {
+	_, err := s3manager.NewUploader(d.sess).UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:               aws.String(d.bucketID),
+		ContentType:          aws.String(contentType),
+		Key:                  aws.String(name),
+		Body:                 body,
+		SSEKMSKeyId:          aws.String(d.kmsKeyID),
+		ServerSideEncryption: aws.String("aws:kms"),
+		Metadata: map[string]*string{
+			"inventory-status":  aws.String(summary.Status()),
+			"collection-errors": aws.String(strconv.Itoa(len(summary.Errors))),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload to bucket: %v", err)
+	}
+	return nil
+}
+
+// Previous ... returns the key and body of the most recent NDJSON report in the bucket
+func (d *s3Destination) Previous(ctx context.Context, prefix, current string) (string, io.ReadCloser, error) {
+	svc := s3.New(d.sess)
+	var objects []*s3.Object
+	err := svc.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(d.bucketID),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		objects = append(objects, page.Contents...)
+		return !lastPage
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list reports in bucket: %v", err)
+	}
+	key := previousReport(objects, prefix, current)
+	if key == "" {
+		return "", nil, errors.New("no previous NDJSON report found")
+	}
+	out, err := svc.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(d.bucketID),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get previous report %s: %v", key, err)
+	}
+	return key, out.Body, nil
+}
+
+// localDestination ... saves reports to a directory, creating it if needed
+type localDestination struct {
+	dir string
+}
+
+// Save ... writes 'body' to the file 'name' in the directory
+func (d *localDestination) Save(ctx context.Context, name, contentType string, body io.Reader, summary *Summary) error {
+	err := os.MkdirAll(d.dir, 0750)
+	if err != nil {
+		return err
+	}
+	name = filepath.Join(d.dir, name)
+	f, err := os.Create(filepath.Clean(name))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("saved report %s\n", name)
+	return nil
+}
+
+// Previous ... returns the path and content of the most recent NDJSON report in the directory
+func (d *localDestination) Previous(ctx context.Context, prefix, current string) (string, io.ReadCloser, error) {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list reports in %s: %v", d.dir, err)
+	}
+	var objects []*s3.Object
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return "", nil, err
+		}
+		objects = append(objects, &s3.Object{Key: aws.String(e.Name()), LastModified: aws.Time(info.ModTime())})
+	}
+	name := previousReport(objects, prefix, current)
+	if name == "" {
+		return "", nil, errors.New("no previous NDJSON report found")
+	}
+	name = filepath.Join(d.dir, name)
+	f, err := os.Open(filepath.Clean(name))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open previous report %s: %v", name, err)
+	}
+	return name, f, nil
+}
+
+// writerDestination ... writes reports to an io.Writer, e.g. os.Stdout
+type writerDestination struct {
+	w io.Writer
+}
+
+// Save ... copies 'body' to the writer
+func (d *writerDestination) Save(ctx context.Context, name, contentType string, body io.Reader, summary *Summary) error {
+	_, err := io.Copy(d.w, body)
+	return err
+}
+
+// Previous ... always returns an error, reports written to a stream can not be read back
+func (d *writerDestination) Previous(ctx context.Context, prefix, current string) (string, io.ReadCloser, error) {
+	return "", nil, errors.New("previous reports can not be read from stdout")
+}
+
+// previousReport ... returns the key of the most recently modified NDJSON report named 'prefix'
+// followed by the time of its run, other than 'current', or an empty string if there is none.
+// Reports of other output names starting with 'prefix', e.g. grace_inventory_dev_, do not match
+func previousReport(objects []*s3.Object, prefix, current string) string {
+	r := regexp.MustCompile("^" + regexp.QuoteMeta(prefix) + reportTimePattern + `\.` + spreadsheet.FormatNDJSON + "$")
+	var latest *s3.Object
+	for _, o := range objects {
+		key := aws.StringValue(o.Key)
+		if key == current || !r.MatchString(key) {
+			continue
+		}
+		if latest == nil || aws.TimeValue(o.LastModified).After(aws.TimeValue(latest.LastModified)) {
+			latest = o
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return aws.StringValue(latest.Key)
+}
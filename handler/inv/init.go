@@ -1,82 +1,69 @@
 package inv
 
 import (
-	"os"
 	"regexp"
 
 	"github.com/GSA/grace-inventory/handler/helpers"
 	"github.com/GSA/grace-inventory/handler/spreadsheet"
 )
 
+// accountListRegex ... matches accounts_info set to a comma delimited list of account IDs
+var accountListRegex = regexp.MustCompile(`^\d{12}`)
+
 func init() {
-	accountsInfo := os.Getenv("accounts_info")
-	r := regexp.MustCompile(`^\d{12}`)
-	if accountsInfo == "self" || r.MatchString(accountsInfo) {
-		spreadsheet.RegisterSheet(helpers.SheetAccounts, func() *spreadsheet.Sheet {
-			return &spreadsheet.Sheet{Name: "Accounts", Columns: []*spreadsheet.Column{
-				{FriendlyName: "Alias", FieldName: "Name"},
-				{FriendlyName: "Id", FieldName: "Id"},
-			}}
-		})
-	} else {
-		spreadsheet.RegisterSheet(helpers.SheetAccounts, func() *spreadsheet.Sheet {
-			return &spreadsheet.Sheet{Name: "Accounts", Columns: []*spreadsheet.Column{
-				{FriendlyName: "Name", FieldName: "Name"},
-				{FriendlyName: "Id", FieldName: "Id"},
-				{FriendlyName: "Status", FieldName: "Status"},
-				{FriendlyName: "Email", FieldName: "Email"},
-				{FriendlyName: "JoinedMethod", FieldName: "JoinedMethod"},
-				{FriendlyName: "JoinedTimestamp", FieldName: "JoinedTimestamp"},
-				{FriendlyName: "Arn", FieldName: "Arn"},
-			}}
-		})
-	}
+	registerAccountsSheet("")
 	spreadsheet.RegisterSheet(helpers.SheetRoles, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "IAM Roles", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "IAM Roles", ARN: "Arn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "RoleName", FieldName: "RoleName"},
-			{FriendlyName: "RoleId", FieldName: "RoleId"},
+			{FriendlyName: "RoleId", FieldName: "RoleId", Key: true},
 			{FriendlyName: "Description", FieldName: "Description"},
 			{FriendlyName: "CreateDate", FieldName: "CreateDate"},
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetGroups, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "IAM Groups", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "IAM Groups", ARN: "Arn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "GroupName", FieldName: "GroupName"},
-			{FriendlyName: "GroupId", FieldName: "GroupId"},
+			{FriendlyName: "GroupId", FieldName: "GroupId", Key: true},
 			{FriendlyName: "CreateDate", FieldName: "CreateDate"},
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetPolicies, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "IAM Policies", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "IAM Policies", ARN: "Arn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "PolicyName", FieldName: "PolicyName"},
-			{FriendlyName: "PolicyId", FieldName: "PolicyId"},
+			{FriendlyName: "PolicyId", FieldName: "PolicyId", Key: true},
 			{FriendlyName: "CreateDate", FieldName: "CreateDate"},
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetUsers, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "IAM Users", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "IAM Users", ARN: "Arn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "UserName", FieldName: "UserName"},
-			{FriendlyName: "UserId", FieldName: "UserId"},
+			{FriendlyName: "UserId", FieldName: "UserId", Key: true},
 			{FriendlyName: "CreateDate", FieldName: "CreateDate"},
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetBuckets, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "S3 Buckets", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
-			{FriendlyName: "Name", FieldName: "Name"},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
+			{FriendlyName: "Name", FieldName: "Name", Key: true},
 			{FriendlyName: "CreateDate", FieldName: "CreationDate"},
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetInstances, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "EC2 Instances", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Name", FieldName: "Tags"},
-			{FriendlyName: "InstanceId", FieldName: "InstanceId"},
+			{FriendlyName: "InstanceId", FieldName: "InstanceId", Key: true},
 			{FriendlyName: "InstanceType", FieldName: "InstanceType"},
 			{FriendlyName: "PrivateIpAddress", FieldName: "PrivateIpAddress"},
 			{FriendlyName: "PublicIpAddress", FieldName: "PublicIpAddress"},
@@ -87,11 +74,12 @@ func init() {
 	})
 	spreadsheet.RegisterSheet(helpers.SheetImages, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "Images", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Name", FieldName: "Tags"},
 			{FriendlyName: "AMI Name", FieldName: "Name"},
-			{FriendlyName: "ImageId", FieldName: "ImageId"},
+			{FriendlyName: "ImageId", FieldName: "ImageId", Key: true},
 			{FriendlyName: "Description", FieldName: "Description"},
 			{FriendlyName: "State", FieldName: "State"},
 			{FriendlyName: "CreationDate", FieldName: "CreationDate"},
@@ -99,9 +87,10 @@ func init() {
 	})
 	spreadsheet.RegisterSheet(helpers.SheetVolumes, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "Volumes", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
-			{FriendlyName: "VolumeId", FieldName: "VolumeId"},
+			{FriendlyName: "VolumeId", FieldName: "VolumeId", Key: true},
 			{FriendlyName: "State", FieldName: "State"},
 			{FriendlyName: "Size", FieldName: "Size"},
 			{FriendlyName: "VolumeType", FieldName: "VolumeType"},
@@ -111,10 +100,11 @@ func init() {
 	})
 	spreadsheet.RegisterSheet(helpers.SheetSnapshots, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "Snapshots", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Name", FieldName: "Tags"},
-			{FriendlyName: "SnapshotId", FieldName: "SnapshotId"},
+			{FriendlyName: "SnapshotId", FieldName: "SnapshotId", Key: true},
 			{FriendlyName: "Description", FieldName: "Description"},
 			{FriendlyName: "State", FieldName: "State"},
 			{FriendlyName: "VolumeId", FieldName: "VolumeId"},
@@ -124,20 +114,22 @@ func init() {
 	})
 	spreadsheet.RegisterSheet(helpers.SheetIgws, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "IGWs", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
-			{FriendlyName: "Id", FieldName: "ID"},
-			{FriendlyName: "VpcId", FieldName: "VpcID"},
+			{FriendlyName: "Id", FieldName: "ID", Key: true},
+			{FriendlyName: "VpcId", FieldName: "VpcID", Key: true},
 			{FriendlyName: "OwnerId", FieldName: "OwnerID"},
 			{FriendlyName: "Status", FieldName: "Status"},
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetVpcs, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "VPCs", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Name", FieldName: "Tags"},
-			{FriendlyName: "VpcId", FieldName: "VpcId"},
+			{FriendlyName: "VpcId", FieldName: "VpcId", Key: true},
 			{FriendlyName: "State", FieldName: "State"},
 			{FriendlyName: "CidrBlock", FieldName: "CidrBlock"},
 			{FriendlyName: "DhcpOptionsId", FieldName: "DhcpOptionsId"},
@@ -145,24 +137,26 @@ func init() {
 	})
 	spreadsheet.RegisterSheet(helpers.SheetVpcPeers, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "VpcPeers", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "AccepterAccountID", FieldName: "AccepterAccountID"},
-			{FriendlyName: "AccepterVpcID", FieldName: "AccepterVpcID"},
+			{FriendlyName: "AccepterVpcID", FieldName: "AccepterVpcID", Key: true},
 			{FriendlyName: "AccepterCidrBlock", FieldName: "AccepterCidrBlock"},
 			{FriendlyName: "RequesterAccountID", FieldName: "RequesterAccountID"},
-			{FriendlyName: "RequesterVpcID", FieldName: "RequesterVpcID"},
+			{FriendlyName: "RequesterVpcID", FieldName: "RequesterVpcID", Key: true},
 			{FriendlyName: "RequesterCidrBlock", FieldName: "RequesterCidrBlock"},
 			{FriendlyName: "StatusCode", FieldName: "StatusCode"},
 			{FriendlyName: "StatusMessage", FieldName: "StatusMessage"},
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetSubnets, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "Subnets", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "Subnets", ARN: "SubnetArn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Name", FieldName: "Tags"},
-			{FriendlyName: "SubnetId", FieldName: "SubnetId"},
+			{FriendlyName: "SubnetId", FieldName: "SubnetId", Key: true},
 			{FriendlyName: "VpcId", FieldName: "VpcId"},
 			{FriendlyName: "State", FieldName: "State"},
 			{FriendlyName: "CidrBlock", FieldName: "CidrBlock"},
@@ -172,45 +166,49 @@ func init() {
 	})
 	spreadsheet.RegisterSheet(helpers.SheetSecurityGroups, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "SecurityGroups", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "GroupName", FieldName: "GroupName"},
-			{FriendlyName: "GroupId", FieldName: "GroupId"},
+			{FriendlyName: "GroupId", FieldName: "GroupId", Key: true},
 			{FriendlyName: "Description", FieldName: "Description"},
 			{FriendlyName: "VpcId", FieldName: "VpcId"},
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetAddresses, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "EC2 IP Addresses", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
-			{FriendlyName: "AllocationId", FieldName: "AllocationId"},
+			{FriendlyName: "AllocationId", FieldName: "AllocationId", Key: true},
 			{FriendlyName: "AssociationId", FieldName: "AssociationId"},
 			{FriendlyName: "Domain", FieldName: "Domain"},
 			{FriendlyName: "InstanceId", FieldName: "InstanceId"},
 			{FriendlyName: "NetworkInterfaceId", FieldName: "NetworkInterfaceId"},
 			{FriendlyName: "NetworkInterfaceOwnerId", FieldName: "NetworkInterfaceOwnerId"},
 			{FriendlyName: "PrivateIpAddress", FieldName: "PrivateIpAddress"},
-			{FriendlyName: "PublicIp", FieldName: "PublicIp"},
+			{FriendlyName: "PublicIp", FieldName: "PublicIp", Key: true},
 			{FriendlyName: "PublicIpv4Pool", FieldName: "PublicIpv4Pool"},
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetKeyPairs, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "Key Pairs", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
-			{FriendlyName: "KeyName", FieldName: "KeyName"},
+			{FriendlyName: "KeyName", FieldName: "KeyName", Key: true},
 			{FriendlyName: "KeyFingerprint", FieldName: "KeyFingerprint"},
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetStacks, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "CloudFormation Stacks", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "CloudFormation Stacks", ARN: "StackId", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "StackName", FieldName: "StackName"},
 			{FriendlyName: "Description", FieldName: "Description"},
 			{FriendlyName: "RootId", FieldName: "RootId"},
-			{FriendlyName: "StackId", FieldName: "StackId"},
+			{FriendlyName: "StackId", FieldName: "StackId", Key: true},
 			{FriendlyName: "ParentId", FieldName: "ParentId"},
 			{FriendlyName: "RoleARN", FieldName: "RoleARN"},
 			{FriendlyName: "CreationTime", FieldName: "CreationTime"},
@@ -223,12 +221,13 @@ func init() {
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetAlarms, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "Alarms", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "Alarms", ARN: "AlarmArn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Name", FieldName: "AlarmName"},
 			{FriendlyName: "Description", FieldName: "AlarmDescription"},
-			{FriendlyName: "AlarmArn", FieldName: "AlarmArn"},
+			{FriendlyName: "AlarmArn", FieldName: "AlarmArn", Key: true},
 			{FriendlyName: "ActionsEnabled", FieldName: "ActionsEnabled"},
 			{FriendlyName: "Updated", FieldName: "AlarmConfigurationUpdatedTimestamp"},
 			{FriendlyName: "ComparisonOperator", FieldName: "ComparisonOperator"},
@@ -250,13 +249,14 @@ func init() {
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetConfigRules, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "Config Rules", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "Config Rules", ARN: "ConfigRuleArn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Name", FieldName: "ConfigRuleName"},
 			{FriendlyName: "Description", FieldName: "Description"},
 			{FriendlyName: "ConfigRuleId", FieldName: "ConfigRuleId"},
-			{FriendlyName: "ConfigRuleArn", FieldName: "ConfigRuleArn"},
+			{FriendlyName: "ConfigRuleArn", FieldName: "ConfigRuleArn", Key: true},
 			{FriendlyName: "ConfigRuleState", FieldName: "ConfigRuleState"},
 			{FriendlyName: "CreatedBy", FieldName: "CreatedBy"},
 			{FriendlyName: "InputParameters", FieldName: "InputParameters"},
@@ -264,15 +264,16 @@ func init() {
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetLoadBalancers, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "Load Balancers", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "Load Balancers", ARN: "LoadBalancerArn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Name", FieldName: "LoadBalancerName"},
 			{FriendlyName: "DNSName", FieldName: "DNSName"},
 			{FriendlyName: "CanonicalHostedZoneId", FieldName: "CanonicalHostedZoneId"},
 			{FriendlyName: "CreatedTime", FieldName: "CreatedTime"},
 			{FriendlyName: "IpAddressType", FieldName: "IpAddressType"},
-			{FriendlyName: "LoadBalancerArn", FieldName: "LoadBalancerArn"},
+			{FriendlyName: "LoadBalancerArn", FieldName: "LoadBalancerArn", Key: true},
 			{FriendlyName: "Scheme", FieldName: "Scheme"},
 			{FriendlyName: "State", FieldName: "State"},
 			{FriendlyName: "Type", FieldName: "Type"},
@@ -280,11 +281,12 @@ func init() {
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetVaults, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "Glacier Vaults", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "Glacier Vaults", ARN: "VaultARN", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Name", FieldName: "VaultName"},
-			{FriendlyName: "VaultARN", FieldName: "VaultARN"},
+			{FriendlyName: "VaultARN", FieldName: "VaultARN", Key: true},
 			{FriendlyName: "SizeInBytes", FieldName: "SizeInBytes"},
 			{FriendlyName: "NumberOfArchives", FieldName: "NumberOfArchives"},
 			{FriendlyName: "CreationDate", FieldName: "CreationDate"},
@@ -292,11 +294,12 @@ func init() {
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetKeys, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "KMS Keys", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "KMS Keys", ARN: "Arn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "AliasName", FieldName: "AliasName"},
-			{FriendlyName: "Arn", FieldName: "Arn"},
+			{FriendlyName: "Arn", FieldName: "Arn", Key: true},
 			{FriendlyName: "KeyId", FieldName: "KeyID"},
 			{FriendlyName: "CloudHsmClusterId", FieldName: "CloudHsmClusterID"},
 			{FriendlyName: "CreationDate", FieldName: "CreationDate"},
@@ -313,8 +316,9 @@ func init() {
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetDBInstances, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "RDS DB Instances", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "RDS DB Instances", ARN: "DBInstanceArn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "AvailabilityZone", FieldName: "AvailabilityZone"},
 			{FriendlyName: "DBClusterIdentifier", FieldName: "DBClusterIdentifier"},
@@ -323,7 +327,7 @@ func init() {
 			{FriendlyName: "Engine", FieldName: "Engine"},
 			{FriendlyName: "EngineVersion", FieldName: "EngineVersion"},
 			{FriendlyName: "Endpoint", FieldName: "Endpoint"},
-			{FriendlyName: "DBInstanceArn", FieldName: "DBInstanceArn"},
+			{FriendlyName: "DBInstanceArn", FieldName: "DBInstanceArn", Key: true},
 			{FriendlyName: "DBInstanceClass", FieldName: "DBInstanceClass"},
 			{FriendlyName: "DBInstanceStatus", FieldName: "DBInstanceStatus"},
 			{FriendlyName: "MultiAZ", FieldName: "MultiAZ"},
@@ -333,13 +337,14 @@ func init() {
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetDBSnapshots, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "RDS DB Snapshots", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "RDS DB Snapshots", ARN: "DBSnapshotArn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "AllocatedStorage", FieldName: "AllocatedStorage"},
 			{FriendlyName: "AvailabilityZone", FieldName: "AvailabilityZone"},
 			{FriendlyName: "DBInstanceIdentifier", FieldName: "DBInstanceIdentifier"},
-			{FriendlyName: "DBSnapshotArn", FieldName: "DBSnapshotArn"},
+			{FriendlyName: "DBSnapshotArn", FieldName: "DBSnapshotArn", Key: true},
 			{FriendlyName: "DBSnapshotIdentifier", FieldName: "DBSnapshotIdentifier"},
 			{FriendlyName: "DbiResourceId", FieldName: "DbiResourceId"},
 			{FriendlyName: "Encrypted", FieldName: "Encrypted"},
@@ -356,12 +361,13 @@ func init() {
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetSecrets, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "Secrets", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "Secrets", ARN: "ARN", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Name", FieldName: "Name"},
 			{FriendlyName: "Description", FieldName: "Description"},
-			{FriendlyName: "ARN", FieldName: "ARN"},
+			{FriendlyName: "ARN", FieldName: "ARN", Key: true},
 			{FriendlyName: "DeletedDate", FieldName: "DeletedDate"},
 			{FriendlyName: "KmsKeyId", FieldName: "KmsKeyId"},
 			{FriendlyName: "LastAccessedDate", FieldName: "LastAccessedDate"},
@@ -371,22 +377,24 @@ func init() {
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetSubscriptions, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "SNS Subscriptions", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "SNS Subscriptions", ARN: "SubscriptionArn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Endpoint", FieldName: "Endpoint"},
 			{FriendlyName: "Owner", FieldName: "Owner"},
 			{FriendlyName: "Protocol", FieldName: "Protocol"},
-			{FriendlyName: "SubscriptionArn", FieldName: "SubscriptionArn"},
+			{FriendlyName: "SubscriptionArn", FieldName: "SubscriptionArn", Key: true},
 			{FriendlyName: "TopicArn", FieldName: "TopicArn"},
 		}}
 	})
 	spreadsheet.RegisterSheet(helpers.SheetTopics, func() *spreadsheet.Sheet {
-		return &spreadsheet.Sheet{Name: "SNS Topics", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+		return &spreadsheet.Sheet{Name: "SNS Topics", ARN: "TopicArn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
 			{FriendlyName: "Name", FieldName: "DisplayName"},
-			{FriendlyName: "TopicArn", FieldName: "TopicArn"},
+			{FriendlyName: "TopicArn", FieldName: "TopicArn", Key: true},
 			{FriendlyName: "Owner", FieldName: "Owner"},
 			{FriendlyName: "SubscriptionsPending", FieldName: "SubscriptionsPending"},
 			{FriendlyName: "SubscriptionsConfirmed", FieldName: "SubscriptionsConfirmed"},
@@ -397,9 +405,10 @@ func init() {
 	})
 	spreadsheet.RegisterSheet(helpers.SheetParameters, func() *spreadsheet.Sheet {
 		return &spreadsheet.Sheet{Name: "SSM Parameters", Columns: []*spreadsheet.Column{
-			{FriendlyName: "Account", FieldName: ""},
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
 			{FriendlyName: "Region", FieldName: ""},
-			{FriendlyName: "Name", FieldName: "Name"},
+			{FriendlyName: "Name", FieldName: "Name", Key: true},
 			{FriendlyName: "Description", FieldName: "Description"},
 			{FriendlyName: "KeyId", FieldName: "KeyId"},
 			{FriendlyName: "AllowedPattern", FieldName: "AllowedPattern"},
@@ -410,4 +419,91 @@ func init() {
 			{FriendlyName: "LastModifiedUser", FieldName: "LastModifiedUser"},
 		}}
 	})
+	spreadsheet.RegisterSheet(helpers.SheetAccountAccess, func() *spreadsheet.Sheet {
+		return &spreadsheet.Sheet{Name: "AccountAccess", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: "AccountID", Key: true},
+			{FriendlyName: "Account Name", FieldName: "AccountName"},
+			{FriendlyName: "Reachable", FieldName: "Reachable"},
+			{FriendlyName: "Arn", FieldName: "Arn"},
+			{FriendlyName: "Code", FieldName: "Code"},
+			{FriendlyName: "Message", FieldName: "Message"},
+		}}
+	})
+	spreadsheet.RegisterSheet(helpers.SheetRegions, func() *spreadsheet.Sheet {
+		return &spreadsheet.Sheet{Name: "Regions", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: "AccountID", Key: true},
+			{FriendlyName: "Account Name", FieldName: "AccountName"},
+			{FriendlyName: "Region", FieldName: "Region", Key: true},
+			{FriendlyName: "OptInStatus", FieldName: "OptInStatus"},
+			{FriendlyName: "Scanned", FieldName: "Scanned"},
+		}}
+	})
+	spreadsheet.RegisterSheet(helpers.SheetOrgUnits, func() *spreadsheet.Sheet {
+		return &spreadsheet.Sheet{Name: "Organizational Units", ARN: "Arn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Id", FieldName: "Id", Key: true},
+			{FriendlyName: "Name", FieldName: "Name"},
+			{FriendlyName: "ParentId", FieldName: "ParentID"},
+			{FriendlyName: "ParentPath", FieldName: "ParentPath"},
+			{FriendlyName: "Arn", FieldName: "Arn"},
+		}}
+	})
+	spreadsheet.RegisterSheet(helpers.SheetSCPs, func() *spreadsheet.Sheet {
+		return &spreadsheet.Sheet{Name: "Service Control Policies", ARN: "Arn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Id", FieldName: "Id", Key: true},
+			{FriendlyName: "Name", FieldName: "Name"},
+			{FriendlyName: "Description", FieldName: "Description"},
+			{FriendlyName: "AwsManaged", FieldName: "AwsManaged"},
+			{FriendlyName: "Targets", FieldName: "Targets"},
+			{FriendlyName: "Arn", FieldName: "Arn"},
+		}}
+	})
+	spreadsheet.RegisterSheet(helpers.SheetDelegatedAdmins, func() *spreadsheet.Sheet {
+		return &spreadsheet.Sheet{Name: "Delegated Administrators", ARN: "Arn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Id", FieldName: "Id", Key: true},
+			{FriendlyName: "Name", FieldName: "Name"},
+			{FriendlyName: "Email", FieldName: "Email"},
+			{FriendlyName: "Status", FieldName: "Status"},
+			{FriendlyName: "ServicePrincipals", FieldName: "ServicePrincipals"},
+			{FriendlyName: "DelegationEnabledDate", FieldName: "DelegationEnabledDate"},
+			{FriendlyName: "Arn", FieldName: "Arn"},
+		}}
+	})
+	spreadsheet.RegisterSheet(helpers.SheetErrors, func() *spreadsheet.Sheet {
+		return &spreadsheet.Sheet{Name: "Collection Errors", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Account Id", FieldName: "Account"},
+			{FriendlyName: "Account Name", FieldName: "AccountName"},
+			{FriendlyName: "Region", FieldName: "Region"},
+			{FriendlyName: "Sheet", FieldName: "Sheet"},
+			{FriendlyName: "Code", FieldName: "Code"},
+			{FriendlyName: "Message", FieldName: "Message"},
+			{FriendlyName: "Timestamp", FieldName: "Timestamp"},
+		}}
+	})
+}
+
+// registerAccountsSheet ... registers the Accounts sheet for the accounts source 'accountsInfo',
+// called by NewWithConfig once the configuration is final. Accounts listed by ID, or the account
+// itself, only have an alias and an ID, other sources list every column of the Organizations API
+func registerAccountsSheet(accountsInfo string) {
+	if accountsInfo == "self" || accountListRegex.MatchString(accountsInfo) {
+		spreadsheet.RegisterSheet(helpers.SheetAccounts, func() *spreadsheet.Sheet {
+			return &spreadsheet.Sheet{Name: "Accounts", ARN: "Arn", Columns: []*spreadsheet.Column{
+				{FriendlyName: "Alias", FieldName: "Name"},
+				{FriendlyName: "Id", FieldName: "Id", Key: true},
+			}}
+		})
+		return
+	}
+	spreadsheet.RegisterSheet(helpers.SheetAccounts, func() *spreadsheet.Sheet {
+		return &spreadsheet.Sheet{Name: "Accounts", ARN: "Arn", Columns: []*spreadsheet.Column{
+			{FriendlyName: "Name", FieldName: "Name"},
+			{FriendlyName: "Id", FieldName: "Id", Key: true},
+			{FriendlyName: "Status", FieldName: "Status"},
+			{FriendlyName: "Email", FieldName: "Email"},
+			{FriendlyName: "JoinedMethod", FieldName: "JoinedMethod"},
+			{FriendlyName: "JoinedTimestamp", FieldName: "JoinedTimestamp"},
+			{FriendlyName: "Arn", FieldName: "Arn"},
+			{FriendlyName: "OUPath", FieldName: "OUPath"},
+		}}
+	})
 }
@@ -1,15 +1,23 @@
 package inv
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"path"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
@@ -29,28 +37,47 @@ import (
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
-	"github.com/caarlos0/env/v6"
 
 	"github.com/GSA/grace-inventory/handler/helpers"
 	"github.com/GSA/grace-inventory/handler/helpers/accounts"
 	"github.com/GSA/grace-inventory/handler/helpers/credmgr"
+	"github.com/GSA/grace-inventory/handler/helpers/scheduler"
 	"github.com/GSA/grace-inventory/handler/helpers/sessionmgr"
 	"github.com/GSA/grace-inventory/handler/spreadsheet"
 )
 
-// config ... struct for holding environment variables
-type config struct {
-	BucketID        string   `env:"s3_bucket,required"`
-	KmsKeyID        string   `env:"kms_key_id,required"`
-	Regions         []string `env:"regions,required" envSeparator:","`
-	AccountsInfo    string   `env:"accounts_info" envDefault:"self"`
-	MasterAccountID string   `env:"master_account_id" envDefault:""`
-	OrgUnits        []string `env:"organizational_units" envSeparator:","`
-	MasterRoleName  string   `env:"master_role_name" envDefault:""`
-	TenantRoleName  string   `env:"tenant_role_name" envDefault:""`
+// hasFormat ... returns true if 'format' is one of 'formats'
+func hasFormat(formats []string, format string) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFormats ... validates and de-duplicates the output formats provided
+func parseFormats(formats []string) ([]string, error) {
+	var parsed []string
+	seen := make(map[string]bool)
+	for _, f := range formats {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" || seen[f] {
+			continue
+		}
+		if _, err := spreadsheet.NewSink(f); err != nil {
+			return nil, err
+		}
+		seen[f] = true
+		parsed = append(parsed, f)
+	}
+	if len(parsed) == 0 {
+		return nil, errors.New("at least one output format is required")
+	}
+	return parsed, nil
 }
 
-type queryFunc func() ([]*spreadsheet.Payload, error)
+type queryFunc func(context.Context) ([]*spreadsheet.Payload, error)
 
 type queryError struct {
 	M string
@@ -60,6 +87,11 @@ type queryError struct {
 func (q queryError) Error() string {
 	return q.M
 }
+
+// Unwrap ... returns the underlying error
+func (q queryError) Unwrap() error {
+	return q.E
+}
 func newQueryErrorf(err error, format string, params ...interface{}) queryError {
 	return queryError{E: err, M: fmt.Sprintf(format, params...)}
 }
@@ -68,6 +100,114 @@ type done struct {
 	Name string
 }
 
+// failed ... sent by a query when its queryFunc returns an error
+type failed struct {
+	Name string
+	Err  error
+}
+
+// scope ... describes how a sheet's queryFunc fans out over accounts and regions
+type scope int
+
+const (
+	// scopeUnknown ... the scope of sheets missing from sheetScopes, which are not walked
+	scopeUnknown scope = iota
+	// scopeGlobal ... queried once per account using the default session
+	scopeGlobal
+	// scopeRegional ... queried once per account for every session in the SessionMgr
+	scopeRegional
+)
+
+// sheetScopes ... classifies every sheet with a queryFunc as either global or regional,
+// IAM and S3 ListBuckets are global services, everything else is regional
+var sheetScopes = map[string]scope{
+	helpers.SheetRoles:          scopeGlobal,
+	helpers.SheetGroups:         scopeGlobal,
+	helpers.SheetPolicies:       scopeGlobal,
+	helpers.SheetUsers:          scopeGlobal,
+	helpers.SheetBuckets:        scopeGlobal,
+	helpers.SheetInstances:      scopeRegional,
+	helpers.SheetImages:         scopeRegional,
+	helpers.SheetVolumes:        scopeRegional,
+	helpers.SheetSnapshots:      scopeRegional,
+	helpers.SheetIgws:           scopeRegional,
+	helpers.SheetVpcs:           scopeRegional,
+	helpers.SheetVpcPeers:       scopeRegional,
+	helpers.SheetSubnets:        scopeRegional,
+	helpers.SheetSecurityGroups: scopeRegional,
+	helpers.SheetAddresses:      scopeRegional,
+	helpers.SheetKeyPairs:       scopeRegional,
+	helpers.SheetStacks:         scopeRegional,
+	helpers.SheetAlarms:         scopeRegional,
+	helpers.SheetConfigRules:    scopeRegional,
+	helpers.SheetLoadBalancers:  scopeRegional,
+	helpers.SheetVaults:         scopeRegional,
+	helpers.SheetKeys:           scopeRegional,
+	helpers.SheetDBInstances:    scopeRegional,
+	helpers.SheetDBSnapshots:    scopeRegional,
+	helpers.SheetSecrets:        scopeRegional,
+	helpers.SheetSubscriptions:  scopeRegional,
+	helpers.SheetTopics:         scopeRegional,
+	helpers.SheetParameters:     scopeRegional,
+}
+
+// sheetServices ... the AWS service queried by each sheet, used to select the rate limiter
+var sheetServices = map[string]string{
+	helpers.SheetRoles:           "iam",
+	helpers.SheetGroups:          "iam",
+	helpers.SheetPolicies:        "iam",
+	helpers.SheetUsers:           "iam",
+	helpers.SheetBuckets:         "s3",
+	helpers.SheetInstances:       "ec2",
+	helpers.SheetImages:          "ec2",
+	helpers.SheetVolumes:         "ec2",
+	helpers.SheetSnapshots:       "ec2",
+	helpers.SheetIgws:            "ec2",
+	helpers.SheetVpcs:            "ec2",
+	helpers.SheetVpcPeers:        "ec2",
+	helpers.SheetSubnets:         "ec2",
+	helpers.SheetSecurityGroups:  "ec2",
+	helpers.SheetAddresses:       "ec2",
+	helpers.SheetKeyPairs:        "ec2",
+	helpers.SheetStacks:          "cloudformation",
+	helpers.SheetAlarms:          "cloudwatch",
+	helpers.SheetConfigRules:     "config",
+	helpers.SheetLoadBalancers:   "elasticloadbalancing",
+	helpers.SheetVaults:          "glacier",
+	helpers.SheetKeys:            "kms",
+	helpers.SheetDBInstances:     "rds",
+	helpers.SheetDBSnapshots:     "rds",
+	helpers.SheetSecrets:         "secretsmanager",
+	helpers.SheetSubscriptions:   "sns",
+	helpers.SheetTopics:          "sns",
+	helpers.SheetParameters:      "ssm",
+	helpers.SheetOrgUnits:        "organizations",
+	helpers.SheetSCPs:            "organizations",
+	helpers.SheetDelegatedAdmins: "organizations",
+}
+
+// walkFunc ... called by the walkers once per account, or once per account and region
+type walkFunc func(accountRef, *credentials.Credentials, *session.Session) (*spreadsheet.Payload, error)
+
+// accountRef ... identifies an account passed to a walkFunc by its ID, with its display name carried separately
+type accountRef struct {
+	ID   string
+	Name string
+}
+
+// String ... returns the name and ID of the account, or only the ID if the account has no name
+func (a accountRef) String() string {
+	if a.Name == "" || a.Name == a.ID {
+		return a.ID
+	}
+	return fmt.Sprintf("%s (%s)", a.Name, a.ID)
+}
+
+// newAccountRef ... returns the accountRef of 'a'
+func newAccountRef(a *organizations.Account) accountRef {
+	return accountRef{ID: aws.StringValue(a.Id), Name: aws.StringValue(a.Name)}
+}
+
 func getCallerFunc() string {
 	pc := make([]uintptr, 1)
 	if runtime.Callers(3, pc) == 0 {
@@ -82,10 +222,14 @@ var knownErrors = map[string]interface{}{
 	"AccessDeniedException": nil,
 	"AuthorizationError":    nil,
 	"UnauthorizedOperation": nil,
-	"ThrottlingException":   nil,
 }
 
 func isKnownError(err error) bool {
+	// throttling that outlasted every retry is skipped like any other known error
+	var retryErr *scheduler.RetryError
+	if errors.As(err, &retryErr) {
+		return true
+	}
 	if queryErr, ok := err.(queryError); ok {
 		if awsErr, ok := queryErr.E.(awserr.Error); ok {
 			_, ok := knownErrors[awsErr.Code()]
@@ -95,6 +239,16 @@ func isKnownError(err error) bool {
 	return false
 }
 
+// isCanceled ... returns true if err was caused by the context being done, either
+// before the query was started or while the SDK was sending the request
+func isCanceled(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == request.CanceledErrorCode
+}
+
 func logDuration() func() {
 	caller := getCallerFunc()
 	start := time.Now()
@@ -107,79 +261,162 @@ func logDuration() func() {
 // Inv ... is used to manage the spreadsheet and sessions required to generate the AWS report
 type Inv struct {
 	spreadsheet     *spreadsheet.Spreadsheet
+	formats         []string
+	driftReport     bool
+	drift           *spreadsheet.Drift
+	reportPrefix    string
 	mgmtAccount     string
-	bucketID        string
-	kmsKeyID        string
+	destination     Destination
 	defaultRegion   string
 	regions         []string
 	accountsInfo    string
 	masterAccountID string
 	orgUnits        []string
+	recursiveOUs    bool
+	excludeOUs      []string
+	accountFilter   *accounts.Filter
 	masterRoleName  string
 	tenantRoleName  string
+	roleChain       []string         // role ARNs assumed before the master and tenant roles
+	chained         *session.Session // the default session using the credentials of the role chain
+	chainOnce       sync.Once
 	sessionMgr      *sessionmgr.SessionMgr
+	optInCheck      bool
+	accountRegions  map[string]map[string]bool // enabled regions by account ID
+	regionRows      []interface{}
+	unreachable     map[string]bool // accounts that failed the access check by account ID
+	accessRows      []interface{}
 	credMgr         *credmgr.CredMgr
+	scheduler       *scheduler.Scheduler
 	accounts        []*organizations.Account
+	settings        map[string]accounts.Settings // settings read from the account source by account ID
+	accountRoles    map[string]credmgr.Role      // assume role settings from 'account_roles' by account ID
+	partialResults  bool
+	deadlineMargin  time.Duration
 	out             chan interface{}
-	errc            chan error
+	errc            chan *failed
+	quit            chan struct{}
+	wg              sync.WaitGroup
 	queries         map[string]queryFunc
 	running         []string
+	mu              sync.Mutex
+	units           int
+	unexpected      int
+	errors          []*helpers.CollectionError
+}
+
+// Summary ... describes how complete the inventory was, returned by Run
+type Summary struct {
+	Accounts int
+	Units    int
+	Errors   []*helpers.CollectionError
+	// Unexpected ... the number of Errors that would have stopped
+	// the report if partial results were not enabled
+	Unexpected int
+	// Truncated ... true if the deadline was reached before all queries were started
+	Truncated bool
+}
+
+// Complete ... returns true if every account/region/service query succeeded
+func (s *Summary) Complete() bool {
+	return len(s.Errors) == 0
+}
+
+// Status ... returns "truncated" if the deadline was reached, "incomplete" if any
+// unexpected errors were recorded, otherwise "complete"
+func (s *Summary) Status() string {
+	if s.Truncated {
+		return "truncated"
+	}
+	if s.Unexpected > 0 {
+		return "incomplete"
+	}
+	return "complete"
 }
 
-// New ... returns an *Inv, after storing all known queryFunc and creating the *SessionMgr
-func New() (*Inv, error) {
-	cfg := config{}
-	err := env.Parse(&cfg)
+func (s *Summary) String() string {
+	pct := 100.0
+	if s.Units > 0 {
+		pct = float64(s.Units-len(s.Errors)) / float64(s.Units) * 100
+	}
+	msg := fmt.Sprintf("%d accounts, %d of %d queries succeeded (%.1f%%), %d collection errors",
+		s.Accounts, s.Units-len(s.Errors), s.Units, pct, len(s.Errors))
+	if status := s.Status(); status != "complete" {
+		msg = fmt.Sprintf("%s, report is %s", msg, status)
+	}
+	return msg
+}
+
+// New ... returns an *Inv configured from the environment, see LoadConfig and NewWithConfig
+func New(ctx context.Context) (*Inv, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return NewWithConfig(ctx, cfg)
+}
+
+// NewWithConfig ... returns an *Inv, after validating 'cfg', registering the Accounts sheet for its accounts
+// source, storing all known queryFunc and creating the *SessionMgr, discovering the regions with 'ctx'
+func NewWithConfig(ctx context.Context, cfg *Config) (*Inv, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	// the columns of the Accounts sheet depend on the accounts source, which may be overridden
+	registerAccountsSheet(cfg.AccountsInfo)
+	limits, err := scheduler.ParseLimits(cfg.RateLimits)
+	if err != nil {
+		return nil, err
+	}
+	formats, err := parseFormats(cfg.OutputFormats)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := cfg.Roles()
 	if err != nil {
 		return nil, err
 	}
-	defaultRegion := cfg.Regions[0]
+	filter, err := accounts.ParseFilter(cfg.AccountInclude, cfg.AccountExclude)
+	if err != nil {
+		return nil, err
+	}
+	// the change report compares against the NDJSON report of the previous run
+	if cfg.DriftReport && !hasFormat(formats, spreadsheet.FormatNDJSON) {
+		formats = append(formats, spreadsheet.FormatNDJSON)
+	}
+	defaultRegion := cfg.DefaultRegion()
+	sched := scheduler.New(cfg.MaxWorkers, limits)
+	sched.Retry.Attempts = cfg.RetryAttempts
 	inv := &Inv{
-		bucketID:        cfg.BucketID,
-		kmsKeyID:        cfg.KmsKeyID,
 		defaultRegion:   defaultRegion,
 		regions:         cfg.Regions,
 		accountsInfo:    cfg.AccountsInfo,
 		masterAccountID: cfg.MasterAccountID,
 		orgUnits:        cfg.OrgUnits,
+		recursiveOUs:    cfg.RecursiveOUs,
+		excludeOUs:      cfg.ExcludeOUs,
+		accountFilter:   filter,
 		masterRoleName:  cfg.MasterRoleName,
 		tenantRoleName:  cfg.TenantRoleName,
+		accountRoles:    roles,
+		roleChain:       cfg.RoleChain,
+		scheduler:       sched,
+		partialResults:  cfg.PartialResults,
+		deadlineMargin:  cfg.DeadlineMargin,
+		formats:         formats,
+		driftReport:     cfg.DriftReport,
+		reportPrefix:    cfg.ReportPrefix(),
+		optInCheck:      sessionmgr.IsDiscovery(cfg.Regions),
 		out:             make(chan interface{}),
-		errc:            make(chan error),
+		errc:            make(chan *failed),
+		quit:            make(chan struct{}),
 	}
 	//store available queries for referencing
-	inv.queries = map[string]queryFunc{
-		helpers.SheetRoles:          inv.queryRoles,
-		helpers.SheetGroups:         inv.queryGroups,
-		helpers.SheetPolicies:       inv.queryPolicies,
-		helpers.SheetUsers:          inv.queryUsers,
-		helpers.SheetBuckets:        inv.queryBuckets,
-		helpers.SheetInstances:      inv.queryInstances,
-		helpers.SheetImages:         inv.queryImages,
-		helpers.SheetVolumes:        inv.queryVolumes,
-		helpers.SheetSnapshots:      inv.querySnapshots,
-		helpers.SheetIgws:           inv.queryIgws,
-		helpers.SheetVpcs:           inv.queryVpcs,
-		helpers.SheetVpcPeers:       inv.queryVpcPeers,
-		helpers.SheetSubnets:        inv.querySubnets,
-		helpers.SheetSecurityGroups: inv.querySecurityGroups,
-		helpers.SheetAddresses:      inv.queryAddresses,
-		helpers.SheetKeyPairs:       inv.queryKeyPairs,
-		helpers.SheetStacks:         inv.queryStacks,
-		helpers.SheetAlarms:         inv.queryAlarms,
-		helpers.SheetConfigRules:    inv.queryConfigRules,
-		helpers.SheetLoadBalancers:  inv.queryLoadBalancers,
-		helpers.SheetVaults:         inv.queryVaults,
-		helpers.SheetKeys:           inv.queryKeys,
-		helpers.SheetDBInstances:    inv.queryDBInstances,
-		helpers.SheetDBSnapshots:    inv.queryDBSnapshots,
-		helpers.SheetSecrets:        inv.querySecrets,
-		helpers.SheetSubscriptions:  inv.querySubscriptions,
-		helpers.SheetTopics:         inv.queryTopics,
-		helpers.SheetParameters:     inv.queryParameters,
-	}
-
-	sess, err := session.NewSession(&aws.Config{Region: &defaultRegion})
+	inv.queries = inv.allQueries()
+
+	sessioner := newSessioner(cfg.Profile, cfg.Endpoint)
+	sess, err := sessioner(&aws.Config{Region: &defaultRegion})
 	if err != nil {
 		return nil, err
 	}
@@ -188,53 +425,193 @@ func New() (*Inv, error) {
 	if err != nil {
 		return nil, err
 	}
-	// Set mgmtAccount to the current account
+	// Set mgmtAccount to the current account, or the account of the last role
+	// of the chain, whose credentials are used to query the other accounts
 	inv.mgmtAccount = *identity.Account
+	if len(cfg.RoleChain) > 0 {
+		inv.mgmtAccount = credmgr.ChainAccount(cfg.RoleChain)
+	}
 	inv.sessionMgr = sessionmgr.New(defaultRegion, cfg.Regions)
-	err = inv.sessionMgr.Init()
+	inv.sessionMgr.Sessioner(sessioner)
+	inv.sessionMgr.Filter(cfg.RegionInclude, cfg.RegionExclude)
+	err = inv.sessionMgr.Init(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inv.destination, err = newDestination(cfg, inv.sessionMgr)
 	if err != nil {
 		return nil, err
 	}
 	return inv, nil
 }
 
+// newDestination ... returns the Destination selected by 'cfg'
+func newDestination(cfg *Config, mgr *sessionmgr.SessionMgr) (Destination, error) {
+	switch cfg.DestinationType() {
+	case DestinationLocal:
+		return &localDestination{dir: cfg.OutputDir}, nil
+	case DestinationStdout:
+		return &writerDestination{w: os.Stdout}, nil
+	}
+	sess, err := mgr.Default()
+	if err != nil {
+		return nil, err
+	}
+	return &s3Destination{sess: sess, bucketID: cfg.BucketID, kmsKeyID: cfg.KmsKeyID}, nil
+}
+
+// SetDestination ... replaces the Destination the reports are saved to
+func (inv *Inv) SetDestination(d Destination) {
+	inv.destination = d
+}
+
 // Run ... starts the report process, the corresponding queryFunc for each sheet in the spreadsheet
 // will be ran and the results added to that sheet. Run is a blocking function and will hold the cursor
-// until all queries have been ran and the spreadsheet has been saved to the bucket
-func (inv *Inv) Run(s *spreadsheet.Spreadsheet) error {
+// until all queries have been ran and the spreadsheet has been saved to the bucket. Skipped queries
+// are added to the Errors sheet, and returned in the *Summary along with how complete the inventory was.
+// Queries stop being started 'deadline_margin' before the deadline of 'ctx', whatever was collected
+// by then is saved as a truncated report. Rows are spooled to temporary files until the report is saved,
+// the spreadsheet is closed before returning to remove them
+func (inv *Inv) Run(ctx context.Context, s *spreadsheet.Spreadsheet) (*Summary, error) {
 	inv.spreadsheet = s
-	inv.query(map[string]queryFunc{helpers.SheetAccounts: inv.queryAccounts})
+	defer func() {
+		if err := s.Close(); err != nil {
+			log.Printf("failed to remove temporary files -> %v\n", err)
+		}
+	}()
+	for _, format := range inv.formats {
+		sink, err := spreadsheet.NewSink(format)
+		if err != nil {
+			return nil, err
+		}
+		err = s.AddSink(sink)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if inv.driftReport {
+		err := inv.addDrift(ctx, s)
+		if err != nil {
+			log.Printf("skipping change report -> %v\n", err)
+		}
+	}
+	work, cancel := inv.withDeadline(ctx)
+	defer cancel()
+	inv.query(work, map[string]queryFunc{helpers.SheetAccounts: inv.queryAccounts})
 
-	err := inv.aggregate()
+	err := inv.aggregate(work)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	summary := inv.summary()
+	if work.Err() != nil {
+		log.Printf("stopped collecting -> %v, saving truncated report\n", work.Err())
+		summary.Truncated = true
 	}
-	return inv.save()
+	var items []interface{}
+	for _, e := range summary.Errors {
+		items = append(items, e)
+	}
+	err = s.UpdateSheet(helpers.SheetErrors, &spreadsheet.Payload{Items: items})
+	if err != nil {
+		return nil, err
+	}
+	if inv.drift != nil {
+		inv.skipRemovals(inv.drift, summary)
+	}
+	return summary, inv.save(ctx, summary)
+}
+
+// withDeadline ... returns a context that is done 'deadlineMargin' before the deadline
+// of 'ctx', leaving time to save the report. If 'ctx' has no deadline, the returned
+// context is only done when 'ctx' is
+func (inv *Inv) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(ctx, deadline.Add(-inv.deadlineMargin))
+	}
+	return context.WithCancel(ctx)
+}
+
+// summary ... returns a *Summary of the queries ran so far
+func (inv *Inv) summary() *Summary {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	accounts := 0
+	for _, a := range inv.accounts {
+		if aws.StringValue(a.Status) != "SUSPENDED" {
+			accounts++
+		}
+	}
+	return &Summary{
+		Accounts:   accounts,
+		Units:      inv.units,
+		Errors:     append([]*helpers.CollectionError(nil), inv.errors...),
+		Unexpected: inv.unexpected,
+	}
+}
+
+// record ... counts a completed scheduler.Unit, storing a *helpers.CollectionError if it failed
+func (inv *Inv) record(sheet string, u scheduler.Unit, err error) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	inv.units++
+	if err != nil {
+		e := helpers.NewCollectionError(u.Account, u.Region, sheet, err)
+		e.AccountName = inv.accountName(u.Account)
+		inv.errors = append(inv.errors, e)
+		if !isKnownError(err) && !isCanceled(err) {
+			inv.unexpected++
+		}
+	}
+}
+
+// accountName ... returns the name of the account with ID 'accountID', or an empty string if it is unknown
+func (inv *Inv) accountName(accountID string) string {
+	for _, a := range inv.accounts {
+		if aws.StringValue(a.Id) == accountID {
+			return aws.StringValue(a.Name)
+		}
+	}
+	return ""
 }
 
 // query ... enumerates over the funcs map provided, spawning each func in a new go routine
 // then sending the results over the out channel to be collected by 'aggregate'. As each func
 // is called appends the name of the sheet to 'running' which is used to determine whether
-// all sheets have been completed successfully
-func (inv *Inv) query(funcs map[string]queryFunc) {
+// all sheets have been completed successfully. Concurrency of the underlying API calls
+// is bounded by the Scheduler used by the walkers. If 'aggregate' stops early, closing
+// 'quit' releases any go routine still waiting to send
+func (inv *Inv) query(ctx context.Context, funcs map[string]queryFunc) {
 	for name, fn := range funcs {
-		time.Sleep(3 * time.Second) // offset to avoid throttling
 		inv.running = append(inv.running, name)
-		go func(fn queryFunc, name string, out chan interface{}, errc chan error) {
-			payloads, err := fn()
+		inv.wg.Add(1)
+		go func(fn queryFunc, name string, out chan interface{}, errc chan *failed, quit chan struct{}) {
+			defer inv.wg.Done()
+			payloads, err := fn(ctx)
 			if err != nil {
-				errc <- err
+				select {
+				case errc <- &failed{Name: name, Err: err}:
+				case <-quit:
+					return
+				}
 			}
 			for _, p := range payloads {
-				out <- p
+				select {
+				case out <- p:
+				case <-quit:
+					return
+				}
+			}
+			select {
+			case out <- &done{name}:
+			case <-quit:
 			}
-			out <- &done{name}
-		}(fn, name, inv.out, inv.errc)
+		}(fn, name, inv.out, inv.errc, inv.quit)
 	}
 }
 
 // runAllQueries ... executes remaining queries, excluding Accounts
-func (inv *Inv) runAllQueries() {
+func (inv *Inv) runAllQueries(ctx context.Context) {
 	queries := make(map[string]queryFunc)
 
 	for _, v := range inv.spreadsheet.Sheets {
@@ -243,14 +620,19 @@ func (inv *Inv) runAllQueries() {
 		}
 	}
 
-	inv.query(queries)
+	inv.query(ctx, queries)
 }
 
 // nolint: gocyclo
 // aggregate ... waits for results to be sent on the 'out' channel, then calls 'UpdateSheet'
 // passing the corresponding sheet name for the 'spreadsheet.Payload.Items' type. As sheets
-// are completed, removes the sheet name from 'running' to prevent infinitely looping
-func (inv *Inv) aggregate() error {
+// are completed, removes the sheet name from 'running' to prevent infinitely looping. When
+// partial results are enabled, errors are recorded and aggregate waits for the remaining
+// sheets, otherwise the first error is returned. Errors caused by 'ctx' being done are
+// always recorded. Either way every go routine started by 'query' has exited once aggregate returns
+func (inv *Inv) aggregate(ctx context.Context) error {
+	defer inv.wg.Wait()
+	defer close(inv.quit)
 	// while there are incomplete sheets, loop and wait for completion
 	for len(inv.running) > 0 {
 		select {
@@ -259,7 +641,10 @@ func (inv *Inv) aggregate() error {
 			case *spreadsheet.Payload:
 				sheet, err := helpers.TypeToSheet(val.Items)
 				if err != nil {
-					return err
+					if err := inv.fail("", err); err != nil {
+						return err
+					}
+					break
 				}
 				if sheet == "" {
 					// if the sheet name is empty, the payload is empty
@@ -270,13 +655,21 @@ func (inv *Inv) aggregate() error {
 					// Use accounts to facilitate the creation of the credMgr
 					sess, err := inv.sessionMgr.Default()
 					if err != nil {
-						return err
+						if err := inv.fail(sheet, err); err != nil {
+							return err
+						}
+						break
 					}
-					inv.credMgr = credmgr.New(sess, inv.mgmtAccount, inv.tenantRoleName, inv.accounts)
+					inv.credMgr = credmgr.NewWithRoles(inv.chainedSession(sess), inv.mgmtAccount, inv.tenantRoleName, inv.accounts, inv.roles())
+					inv.checkAccess(ctx)
+					inv.checkRegions(ctx)
 
-					inv.runAllQueries()
+					inv.runAllQueries(ctx)
+				}
+				err = inv.spreadsheet.UpdateSheet(sheet, val)
+				if err != nil {
+					return err
 				}
-				inv.spreadsheet.UpdateSheet(sheet, val)
 			case *done:
 				// Once a sheet is complete, remove it from the slice
 				for i, v := range inv.running {
@@ -286,14 +679,33 @@ func (inv *Inv) aggregate() error {
 					}
 				}
 			}
-		// if any errors occur, return and break the loop
-		case err := <-inv.errc:
-			return err
+		// if any errors occur, either record them or return and break the loop
+		case f := <-inv.errc:
+			if err := inv.fail(f.Name, f.Err); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+// fail ... returns 'err' unless partial results are enabled or the error was caused by
+// the context being done, in which case the error is recorded against the sheet and nil is returned
+func (inv *Inv) fail(sheet string, err error) error {
+	canceled := isCanceled(err)
+	if !inv.partialResults && !canceled {
+		return err
+	}
+	log.Printf("recording error for sheet %q -> %v\n", sheet, err)
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	inv.errors = append(inv.errors, helpers.NewCollectionError("", "", sheet, err))
+	if !canceled {
+		inv.unexpected++
+	}
+	return nil
+}
+
 type stsSvc struct {
 	Client stsiface.STSAPI
 }
@@ -303,135 +715,625 @@ func (svc *stsSvc) getCurrentIdentity() (*sts.GetCallerIdentityOutput, error) {
 	return svc.Client.GetCallerIdentity(&sts.GetCallerIdentityInput{})
 }
 
-// walkAccounts ... loops over all organization accounts, skipping suspended accounts, and calling 'fn'
+// allQueries ... returns the queryFunc of every sheet, by sheet name
+func (inv *Inv) allQueries() map[string]queryFunc {
+	return map[string]queryFunc{
+		helpers.SheetRoles:           inv.queryRoles,
+		helpers.SheetGroups:          inv.queryGroups,
+		helpers.SheetPolicies:        inv.queryPolicies,
+		helpers.SheetUsers:           inv.queryUsers,
+		helpers.SheetBuckets:         inv.queryBuckets,
+		helpers.SheetInstances:       inv.queryInstances,
+		helpers.SheetImages:          inv.queryImages,
+		helpers.SheetVolumes:         inv.queryVolumes,
+		helpers.SheetSnapshots:       inv.querySnapshots,
+		helpers.SheetIgws:            inv.queryIgws,
+		helpers.SheetVpcs:            inv.queryVpcs,
+		helpers.SheetVpcPeers:        inv.queryVpcPeers,
+		helpers.SheetSubnets:         inv.querySubnets,
+		helpers.SheetSecurityGroups:  inv.querySecurityGroups,
+		helpers.SheetAddresses:       inv.queryAddresses,
+		helpers.SheetKeyPairs:        inv.queryKeyPairs,
+		helpers.SheetStacks:          inv.queryStacks,
+		helpers.SheetAlarms:          inv.queryAlarms,
+		helpers.SheetConfigRules:     inv.queryConfigRules,
+		helpers.SheetLoadBalancers:   inv.queryLoadBalancers,
+		helpers.SheetVaults:          inv.queryVaults,
+		helpers.SheetKeys:            inv.queryKeys,
+		helpers.SheetDBInstances:     inv.queryDBInstances,
+		helpers.SheetDBSnapshots:     inv.queryDBSnapshots,
+		helpers.SheetSecrets:         inv.querySecrets,
+		helpers.SheetSubscriptions:   inv.querySubscriptions,
+		helpers.SheetTopics:          inv.queryTopics,
+		helpers.SheetParameters:      inv.queryParameters,
+		helpers.SheetRegions:         inv.queryRegions,
+		helpers.SheetAccountAccess:   inv.queryAccountAccess,
+		helpers.SheetOrgUnits:        inv.queryOrgUnits,
+		helpers.SheetSCPs:            inv.querySCPs,
+		helpers.SheetDelegatedAdmins: inv.queryDelegatedAdmins,
+	}
+}
+
+// walk ... selects walkAccounts or walkSessions for the sheet provided, based on its scope
+func (inv *Inv) walk(ctx context.Context, sheet string, fn walkFunc) ([]*spreadsheet.Payload, error) {
+	sc, ok := sheetScopes[sheet]
+	if !ok {
+		return nil, fmt.Errorf("sheet %q has no scope", sheet)
+	}
+	if sc == scopeGlobal {
+		return inv.walkAccounts(ctx, sheet, fn)
+	}
+	return inv.walkSessions(ctx, sheet, fn)
+}
+
+// walkAccounts ... loops over all organization accounts, skipping suspended and unreachable accounts, and calling 'fn'
 // passing the *credential.Credential for each account, using the default session, collecting all returned payloads
-func (inv *Inv) walkAccounts(fn func(string, *credentials.Credentials, *session.Session) (*spreadsheet.Payload, error)) ([]*spreadsheet.Payload, error) {
-	var payloads []*spreadsheet.Payload
+func (inv *Inv) walkAccounts(ctx context.Context, sheet string, fn walkFunc) ([]*spreadsheet.Payload, error) {
+	sess, err := inv.sessionMgr.Default()
+	if err != nil {
+		return nil, err
+	}
+	return inv.schedule(ctx, sheet, []*session.Session{sess}, fn)
+}
+
+// walkSessions ... loops over all organization accounts, skipping suspended and unreachable accounts,
+// then looping over all sessions in the SessionMgr calling 'fn', collecting all returned payloads
+func (inv *Inv) walkSessions(ctx context.Context, sheet string, fn walkFunc) ([]*spreadsheet.Payload, error) {
+	return inv.schedule(ctx, sheet, inv.sessionMgr.All(), fn)
+}
+
+// schedule ... submits one scheduler.Unit per account and session calling 'fn', then waits for
+// all of them to complete, returning the payloads in account and session order. 'fn' is passed a
+// session of the scheduler, so its requests are rate limited per account, region and service and
+// throttled requests are retried. Regional sheets skip the regions not scanned for the account,
+// global sheets always use the default session. Once 'ctx' is done no more units are started, the
+// units left out are recorded with the context's error
+func (inv *Inv) schedule(ctx context.Context, sheet string, sessions []*session.Session, fn walkFunc) ([]*spreadsheet.Payload, error) {
+	type result struct {
+		unit    scheduler.Unit
+		payload *spreadsheet.Payload
+		err     error
+	}
+	var (
+		results []*result
+		wg      sync.WaitGroup
+	)
 	for _, a := range inv.accounts {
-		if aws.StringValue(a.Status) == "SUSPENDED" {
+		if inv.skipped(a) {
 			continue
 		}
-		cred, err := inv.credMgr.Cred(aws.StringValue(a.Name))
+		account := newAccountRef(a)
+		cred, err := inv.credMgr.Cred(account.ID)
 		if err != nil {
+			wg.Wait()
 			return nil, err
 		}
-		sess, err := inv.sessionMgr.Default()
-		if err != nil {
-			return nil, err
+		for _, s := range sessions {
+			if sheetScopes[sheet] == scopeRegional && !inv.scanned(account.ID, aws.StringValue(s.Config.Region)) {
+				continue
+			}
+			s := s
+			r := &result{unit: scheduler.Unit{Account: account.ID, Region: aws.StringValue(s.Config.Region), Service: sheetServices[sheet]}}
+			results = append(results, r)
+			wg.Add(1)
+			err := inv.scheduler.Go(ctx, r.unit, func() {
+				defer wg.Done()
+				r.err = inv.scheduler.Do(ctx, r.unit, func() (err error) {
+					r.payload, err = fn(account, cred, inv.scheduler.Session(s, r.unit))
+					return err
+				})
+			})
+			if err != nil {
+				r.err = err
+				wg.Done()
+			}
 		}
-		payload, err := fn(aws.StringValue(a.Name), cred, sess)
-		if err != nil {
-			if isKnownError(err) {
-				log.Printf("walkAccounts got an error when called by %s -> %v\n", getCallerFunc(), err)
+	}
+	wg.Wait()
+
+	var payloads []*spreadsheet.Payload
+	for _, r := range results {
+		if r.err != nil {
+			if isKnownError(r.err) || isCanceled(r.err) || inv.partialResults {
+				log.Printf("sheet %q got an error for %s -> %v\n", sheet, r.unit, r.err)
+				inv.record(sheet, r.unit, r.err)
 				continue
 			}
-			return nil, err
+			return nil, r.err
 		}
-		payloads = append(payloads, payload)
+		inv.record(sheet, r.unit, nil)
+		payloads = append(payloads, r.payload)
 	}
 	return payloads, nil
 }
 
-// walkSessions ... loops over all organization accounts, skipping suspended accounts,
-// then looping over all sessions in the SessionMgr calling 'fn', collecting all returned payloads
-func (inv *Inv) walkSessions(fn func(string, *credentials.Credentials, *session.Session) (*spreadsheet.Payload, error)) ([]*spreadsheet.Payload, error) {
-	var payloads []*spreadsheet.Payload
+// checkAccess ... calls sts:GetCallerIdentity with the credentials of every account before it
+// is queried. Accounts whose credentials fail, or resolve to another account, are listed in the
+// AccountAccess sheet with the reason, recorded against it in the Errors sheet and skipped by the
+// walkers, instead of failing once per sheet and region
+func (inv *Inv) checkAccess(ctx context.Context) {
+	inv.unreachable = make(map[string]bool)
+	inv.accessRows = nil
+	sess, sessErr := inv.sessionMgr.Default()
+	type result struct {
+		account accountRef
+		unit    scheduler.Unit
+		arn     string
+		err     error
+	}
+	var (
+		results []*result
+		wg      sync.WaitGroup
+	)
 	for _, a := range inv.accounts {
 		if aws.StringValue(a.Status) == "SUSPENDED" {
 			continue
 		}
-		cred, err := inv.credMgr.Cred(aws.StringValue(a.Name))
+		account := newAccountRef(a)
+		r := &result{account: account, unit: scheduler.Unit{Account: account.ID, Region: inv.defaultRegion, Service: "sts"}}
+		results = append(results, r)
+		if sessErr != nil {
+			r.err = sessErr
+			continue
+		}
+		cred, err := inv.credMgr.Cred(r.unit.Account)
 		if err != nil {
-			return nil, err
+			r.err = err
+			continue
 		}
-		for _, s := range inv.sessionMgr.All() {
-			payload, err := fn(aws.StringValue(a.Name), cred, s)
-			if err != nil {
-				if isKnownError(err) {
-					log.Printf("walkSessions got an error when called by %s -> %v\n", getCallerFunc(), err)
-					continue
+		wg.Add(1)
+		err = inv.scheduler.Go(ctx, r.unit, func() {
+			defer wg.Done()
+			r.err = inv.scheduler.Do(ctx, r.unit, func() (err error) {
+				svc := helpers.StsSvc{Client: stsCreator(inv.scheduler.Session(sess, r.unit), &aws.Config{Credentials: cred})}
+				r.arn, err = svc.Identity(ctx, r.unit.Account)
+				if err != nil {
+					return newQueryErrorf(err, "failed to check access to account: %s -> %v", r.account, err)
 				}
-				return nil, err
+				return nil
+			})
+		})
+		if err != nil {
+			r.err = err
+			wg.Done()
+		}
+	}
+	wg.Wait()
+
+	for _, r := range results {
+		row := &helpers.AccountAccess{
+			AccountID:   r.account.ID,
+			AccountName: r.account.Name,
+			Reachable:   r.err == nil,
+			Arn:         r.arn,
+		}
+		if r.err != nil {
+			log.Printf("skipping unreachable account %s -> %v\n", r.account, r.err)
+			e := helpers.NewCollectionError(r.account.ID, r.unit.Region, helpers.SheetAccountAccess, r.err)
+			row.Code, row.Message = e.Code, e.Message
+			inv.unreachable[r.account.ID] = true
+		}
+		inv.record(helpers.SheetAccountAccess, r.unit, r.err)
+		inv.accessRows = append(inv.accessRows, row)
+	}
+}
+
+// skipped ... returns true if the account is suspended or failed the access check
+func (inv *Inv) skipped(a *organizations.Account) bool {
+	return aws.StringValue(a.Status) == "SUSPENDED" || inv.unreachable[aws.StringValue(a.Id)]
+}
+
+// checkRegions ... decides which regions are scanned for every account. When regions are
+// discovered, the regions of each account are checked with ec2:DescribeRegions and the
+// regions the account has not opted in to are skipped. If the check fails, the error is
+// recorded against the Regions sheet and every region is scanned for the account
+func (inv *Inv) checkRegions(ctx context.Context) {
+	inv.accountRegions = make(map[string]map[string]bool)
+	inv.regionRows = nil
+	sess, sessErr := inv.sessionMgr.Default()
+	type result struct {
+		account accountRef
+		unit    scheduler.Unit
+		regions []*ec2.Region
+		err     error
+	}
+	var (
+		results []*result
+		wg      sync.WaitGroup
+	)
+	for _, a := range inv.accounts {
+		if inv.skipped(a) {
+			continue
+		}
+		account := newAccountRef(a)
+		r := &result{account: account, unit: scheduler.Unit{Account: account.ID, Region: inv.defaultRegion, Service: "ec2"}}
+		results = append(results, r)
+		if !inv.optInCheck {
+			continue
+		}
+		if sessErr != nil {
+			r.err = sessErr
+			continue
+		}
+		cred, err := inv.credMgr.Cred(r.unit.Account)
+		if err != nil {
+			r.err = err
+			continue
+		}
+		wg.Add(1)
+		err = inv.scheduler.Go(ctx, r.unit, func() {
+			defer wg.Done()
+			r.err = inv.scheduler.Do(ctx, r.unit, func() (err error) {
+				svc := helpers.Ec2Svc{Client: ec2Creator(inv.scheduler.Session(sess, r.unit), &aws.Config{Credentials: cred})}
+				r.regions, err = svc.Regions(ctx)
+				if err != nil {
+					return newQueryErrorf(err, "failed to get Regions for account: %s -> %v", r.account, err)
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			r.err = err
+			wg.Done()
+		}
+	}
+	wg.Wait()
+
+	for _, r := range results {
+		status := make(map[string]string)
+		if inv.optInCheck {
+			if r.err != nil {
+				log.Printf("scanning every region for %s -> %v\n", r.account, r.err)
+			}
+			inv.record(helpers.SheetRegions, r.unit, r.err)
+			for _, region := range r.regions {
+				status[aws.StringValue(region.RegionName)] = aws.StringValue(region.OptInStatus)
 			}
-			payloads = append(payloads, payload)
 		}
+		enabled := make(map[string]bool)
+		for _, s := range inv.sessionMgr.All() {
+			region := aws.StringValue(s.Config.Region)
+			optIn, ok := status[region]
+			if !ok && inv.optInCheck && r.err == nil {
+				// not described for the account, e.g. a region of another partition
+				optIn = "not-opted-in"
+			}
+			enabled[region] = optIn != "not-opted-in" && inv.accountScansRegion(r.unit.Account, region)
+			inv.regionRows = append(inv.regionRows, &helpers.AccountRegion{
+				AccountID:   r.account.ID,
+				AccountName: r.account.Name,
+				Region:      region,
+				OptInStatus: optIn,
+				Scanned:     enabled[region],
+			})
+		}
+		inv.accountRegions[r.unit.Account] = enabled
 	}
-	return payloads, nil
 }
 
-// save - saves the report to S3 with the filename provided to New
-func (inv *Inv) save() error {
-	sess, err := inv.sessionMgr.Default()
+// chainedSession ... returns the default session 'sess' using the credentials of the last role
+// of 'role_chain', or 'sess' if it is not set. The chain is created once, so every caller
+// shares its cached credentials
+func (inv *Inv) chainedSession(sess *session.Session) *session.Session {
+	if len(inv.roleChain) == 0 {
+		return sess
+	}
+	inv.chainOnce.Do(func() {
+		inv.chained = credmgr.ChainedSession(sess, inv.roleChain)
+	})
+	return inv.chained
+}
+
+// roles ... returns the assume role settings by account ID, the settings read from the
+// account source with the non-empty fields of 'account_roles' replacing them
+func (inv *Inv) roles() map[string]credmgr.Role {
+	roles := make(map[string]credmgr.Role)
+	for id, s := range inv.settings {
+		roles[id] = credmgr.Role{
+			Name:        s.RoleName,
+			ExternalID:  s.ExternalID,
+			SessionName: s.SessionName,
+			Duration:    time.Duration(s.DurationSeconds) * time.Second,
+		}
+	}
+	for id, r := range inv.accountRoles {
+		role := roles[id]
+		if r.Name != "" {
+			role.Name = r.Name
+		}
+		if r.ExternalID != "" {
+			role.ExternalID = r.ExternalID
+		}
+		if r.SessionName != "" {
+			role.SessionName = r.SessionName
+		}
+		if r.Duration != 0 {
+			role.Duration = r.Duration
+		}
+		roles[id] = role
+	}
+	return roles
+}
+
+// accountScansRegion ... returns true if the account source lists no regions for
+// the account with ID 'accountID', or 'region' is one of them
+func (inv *Inv) accountScansRegion(accountID, region string) bool {
+	regions := inv.settings[accountID].Regions
+	if len(regions) == 0 {
+		return true
+	}
+	for _, r := range regions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// scanned ... returns true if 'region' is scanned for the account with ID 'accountID',
+// regions of accounts that were not checked by checkRegions are always scanned
+func (inv *Inv) scanned(accountID, region string) bool {
+	enabled, ok := inv.accountRegions[accountID]
+	if !ok {
+		return true
+	}
+	return enabled[region]
+}
+
+// queryAccountAccess ... returns whether every account could be reached, decided by checkAccess
+func (inv *Inv) queryAccountAccess(ctx context.Context) ([]*spreadsheet.Payload, error) {
+	return []*spreadsheet.Payload{{Items: inv.accessRows}}, nil
+}
+
+// queryRegions ... returns the regions of every account and whether they were scanned, decided by checkRegions
+func (inv *Inv) queryRegions(ctx context.Context) ([]*spreadsheet.Payload, error) {
+	return []*spreadsheet.Payload{{Items: inv.regionRows}}, nil
+}
+
+// save - saves the report to the destination once for every sink of the spreadsheet, using
+// the filename provided to New with the extension of the sink. Each report is streamed to
+// the destination as it is rendered
+func (inv *Inv) save(ctx context.Context, summary *Summary) error {
+	for _, sink := range inv.spreadsheet.Sinks {
+		r, w := io.Pipe()
+		go func(sink spreadsheet.Sink) {
+			w.CloseWithError(sink.Render(w))
+		}(sink)
+		err := inv.destination.Save(ctx, reportName(inv.spreadsheet.Name, sink.Extension()), sink.ContentType(), r, summary)
+		// unblock Render if the destination stopped reading early
+		r.CloseWithError(err)
+		if err != nil {
+			return fmt.Errorf("failed to save %s report: %v", sink.Extension(), err)
+		}
+	}
+	return nil
+}
+
+// reportName ... replaces the extension of 'name' with 'ext'
+func reportName(name, ext string) string {
+	return strings.TrimSuffix(name, path.Ext(name)) + "." + ext
+}
+
+// addDrift ... adds a *spreadsheet.Drift sink to 's', comparing the report against the
+// NDJSON report of the previous run, read from the destination. Reports are found by the
+// configured output name followed by the time of their run, see Config.Filename
+func (inv *Inv) addDrift(ctx context.Context, s *spreadsheet.Spreadsheet) error {
+	key, body, err := inv.destination.Previous(ctx, inv.reportPrefix, reportName(s.Name, spreadsheet.FormatNDJSON))
 	if err != nil {
 		return err
 	}
-	reader, err := inv.spreadsheet.Bytes()
+	defer body.Close()
+	drift, err := spreadsheet.NewDrift(body)
 	if err != nil {
 		return err
 	}
-
-	svc := s3.New(sess)
-	_, err = svc.PutObject(&s3.PutObjectInput{
-		Bucket:               aws.String(inv.bucketID),
-		ContentType:          aws.String("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"),
-		Key:                  aws.String(inv.spreadsheet.Name),
-		Body:                 reader,
-		SSEKMSKeyId:          aws.String(inv.kmsKeyID),
-		ServerSideEncryption: aws.String("aws:kms"),
-	})
+	err = s.AddSink(drift)
 	if err != nil {
-		return fmt.Errorf("failed to upload report to bucket: %v", err)
+		_ = drift.Close()
+		return err
 	}
+	inv.drift = drift
+	log.Printf("comparing against previous report %s\n", key)
 	return nil
 }
 
-// queryAccounts ... Queries organization accounts, pushes them onto a slice of interface,
-// then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryAccounts() ([]*spreadsheet.Payload, error) {
-	defer logDuration()()
+// skipRemovals ... stops the change report from listing the rows of failed queries as removed.
+// Unreachable accounts are skipped in every sheet, regional sheets only in the region that failed,
+// and every other sheet, global or without a scope like the Regions sheet, in every region. A truncated
+// report lists no removed rows at all, as queries that were never started are not recorded
+func (inv *Inv) skipRemovals(drift *spreadsheet.Drift, summary *Summary) {
+	if summary.Truncated {
+		drift.Skip("", "", "")
+		return
+	}
+	for _, e := range summary.Errors {
+		if e.Sheet == helpers.SheetAccountAccess {
+			drift.Skip(e.Account, "", "")
+			continue
+		}
+		switch sheetScopes[e.Sheet] {
+		case scopeRegional:
+			drift.Skip(e.Account, e.Region, e.Sheet)
+		case scopeGlobal, scopeUnknown:
+			drift.Skip(e.Account, "", e.Sheet)
+		}
+	}
+}
+
+// accountsSvc ... returns an *accounts.Svc using the default session
+func (inv *Inv) accountsSvc() (*accounts.Svc, error) {
 	sess, err := inv.sessionMgr.Default()
 	if err != nil {
 		return nil, newQueryErrorf(err, "failed to get default session from sessionMgr: %v", err)
 	}
-	options := accounts.Options{
+	svc, err := accounts.NewChainedAccountsSvc(sess, inv.chainedSession(sess))
+	if err != nil {
+		return nil, newQueryErrorf(err, "failed to create NewAccountsSvc: %v", err)
+	}
+	return svc, nil
+}
+
+// accountsOptions ... returns the accounts.Options used to list accounts and query the organization
+func (inv *Inv) accountsOptions() accounts.Options {
+	return accounts.Options{
 		AccountsInfo:    inv.accountsInfo,
 		MgmtAccountID:   inv.mgmtAccount,
 		MasterAccountID: inv.masterAccountID,
 		MasterRoleName:  inv.masterRoleName,
 		TenantRoleName:  inv.tenantRoleName,
 		OrgUnits:        inv.orgUnits,
+		Recursive:       inv.recursiveOUs,
+		ExcludeOUs:      inv.excludeOUs,
+		Filter:          inv.accountFilter,
+		Cred:            inv.accountCred,
 	}
-	svc, err := accounts.NewAccountsSvc(sess)
+}
+
+// accountCred ... returns the credentials of the account from a *credmgr.CredMgr created like the
+// one the account is queried with, assuming its role and settings through the role chain
+func (inv *Inv) accountCred(accountID string) (*credentials.Credentials, error) {
+	sess, err := inv.sessionMgr.Default()
 	if err != nil {
-		return nil, newQueryErrorf(err, "failed to create NewAccountsSvc: %v", err)
+		return nil, err
+	}
+	mgr := credmgr.NewWithRoles(inv.chainedSession(sess), inv.mgmtAccount, inv.tenantRoleName, []*organizations.Account{{Id: aws.String(accountID)}}, inv.roles())
+	return mgr.Cred(accountID)
+}
+
+// queryAccounts ... Queries organization accounts, pushes them onto a slice of interface,
+// then returns a slice of *spreadsheet.Payload
+func (inv *Inv) queryAccounts(ctx context.Context) ([]*spreadsheet.Payload, error) {
+	defer logDuration()()
+	svc, err := inv.accountsSvc()
+	if err != nil {
+		return nil, err
 	}
-	accounts, err := svc.AccountsList(options)
+	list, err := svc.AccountsList(ctx, inv.accountsOptions())
 	if err != nil {
 		return nil, newQueryErrorf(err, "failed to get Accounts: %v", err)
 	}
 	var items []interface{}
-	for i, a := range accounts {
+	settings := make(map[string]accounts.Settings)
+	for i, a := range list {
 		// Use Account ID if name/alias is not set
 		if aws.StringValue(a.Name) == "" {
-			accounts[i].Name = a.Id
+			list[i].Name = a.Id
 		}
-		items = append(items, a)
+		items = append(items, &accounts.Account{Account: a, OUPath: svc.OUPath(aws.StringValue(a.Id))})
+		settings[aws.StringValue(a.Id)] = svc.Settings(aws.StringValue(a.Id))
+	}
+	inv.accounts = list
+	inv.settings = settings
+	err = credmgr.ValidateRoles(inv.roles())
+	if err != nil {
+		return nil, newQueryErrorf(err, "failed to get Accounts: %v", err)
 	}
-	inv.accounts = accounts
 	return []*spreadsheet.Payload{
 		{Static: nil, Items: items},
 	}, nil
 }
 
+// queryOrganization ... calls 'fn' with an *accounts.Svc as a single scheduler.Unit of the management
+// account, so its calls to Organizations are rate limited and retried like the walkers, then records
+// the unit against 'sheet'. Known errors are recorded and skipped like those of the walkers
+func (inv *Inv) queryOrganization(ctx context.Context, sheet string, fn func(*accounts.Svc) ([]interface{}, error)) ([]*spreadsheet.Payload, error) {
+	sess, err := inv.sessionMgr.Default()
+	if err != nil {
+		return nil, newQueryErrorf(err, "failed to get default session from sessionMgr: %v", err)
+	}
+	unit := scheduler.Unit{Account: inv.mgmtAccount, Region: inv.defaultRegion, Service: sheetServices[sheet]}
+	var (
+		items   []interface{}
+		unitErr error
+	)
+	done := make(chan struct{})
+	err = inv.scheduler.Go(ctx, unit, func() {
+		defer close(done)
+		unitErr = inv.scheduler.Do(ctx, unit, func() error {
+			svc, err := accounts.NewChainedAccountsSvc(sess, inv.scheduler.Session(inv.chainedSession(sess), unit))
+			if err != nil {
+				return newQueryErrorf(err, "failed to create NewAccountsSvc: %v", err)
+			}
+			items, err = fn(svc)
+			return err
+		})
+	})
+	if err == nil {
+		<-done
+		err = unitErr
+	}
+	if err != nil {
+		if isKnownError(err) || isCanceled(err) || inv.partialResults {
+			log.Printf("sheet %q got an error for %s -> %v\n", sheet, unit, err)
+			inv.record(sheet, unit, err)
+			return nil, nil
+		}
+		return nil, err
+	}
+	inv.record(sheet, unit, nil)
+	return []*spreadsheet.Payload{{Static: nil, Items: items}}, nil
+}
+
+// queryOrgUnits ... queries every organizational unit of the organization
+// pushes them onto a slice of interface, then returns a slice of *spreadsheet.Payload
+func (inv *Inv) queryOrgUnits(ctx context.Context) ([]*spreadsheet.Payload, error) {
+	defer logDuration()()
+	return inv.queryOrganization(ctx, helpers.SheetOrgUnits, func(svc *accounts.Svc) ([]interface{}, error) {
+		units, err := svc.OrganizationalUnits(ctx, inv.accountsOptions())
+		if err != nil {
+			return nil, newQueryErrorf(err, "failed to get Organizational Units: %v", err)
+		}
+		var items []interface{}
+		for _, u := range units {
+			items = append(items, u)
+		}
+		return items, nil
+	})
+}
+
+// querySCPs ... queries the service control policies of the organization and their targets
+// pushes them onto a slice of interface, then returns a slice of *spreadsheet.Payload
+func (inv *Inv) querySCPs(ctx context.Context) ([]*spreadsheet.Payload, error) {
+	defer logDuration()()
+	return inv.queryOrganization(ctx, helpers.SheetSCPs, func(svc *accounts.Svc) ([]interface{}, error) {
+		policies, err := svc.ServiceControlPolicies(ctx, inv.accountsOptions())
+		if err != nil {
+			return nil, newQueryErrorf(err, "failed to get Service Control Policies: %v", err)
+		}
+		var items []interface{}
+		for _, p := range policies {
+			items = append(items, p)
+		}
+		return items, nil
+	})
+}
+
+// queryDelegatedAdmins ... queries the delegated administrators of the organization
+// pushes them onto a slice of interface, then returns a slice of *spreadsheet.Payload
+func (inv *Inv) queryDelegatedAdmins(ctx context.Context) ([]*spreadsheet.Payload, error) {
+	defer logDuration()()
+	return inv.queryOrganization(ctx, helpers.SheetDelegatedAdmins, func(svc *accounts.Svc) ([]interface{}, error) {
+		admins, err := svc.DelegatedAdministrators(ctx, inv.accountsOptions())
+		if err != nil {
+			return nil, newQueryErrorf(err, "failed to get Delegated Administrators: %v", err)
+		}
+		var items []interface{}
+		for _, a := range admins {
+			items = append(items, a)
+		}
+		return items, nil
+	})
+}
+
 // queryRoles ... queries IAM Roles for all organization accounts
 // pushes them onto a slice of interface, then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryRoles() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryRoles(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkAccounts(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetRoles, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.IamSvc{
 			Client: iam.New(sess, &aws.Config{Credentials: cred}),
 		}
-		roles, err := svc.Roles()
+		roles, err := svc.Roles(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Roles for account: %s -> %v", account, err)
 		}
@@ -439,19 +1341,19 @@ func (inv *Inv) queryRoles() ([]*spreadsheet.Payload, error) {
 		for _, r := range roles {
 			items = append(items, r)
 		}
-		return &spreadsheet.Payload{Static: []string{account}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name}, Items: items}, nil
 	})
 }
 
 // queryGroups ... queries IAM Groups for all organization accounts
 // pushes them onto a slice of interface, then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryGroups() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryGroups(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkAccounts(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetGroups, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.IamSvc{
 			Client: iam.New(sess, &aws.Config{Credentials: cred}),
 		}
-		groups, err := svc.Groups()
+		groups, err := svc.Groups(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Groups for account: %s -> %v", account, err)
 		}
@@ -459,19 +1361,19 @@ func (inv *Inv) queryGroups() ([]*spreadsheet.Payload, error) {
 		for _, g := range groups {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name}, Items: items}, nil
 	})
 }
 
 // queryPolicies ... queries IAM Groups for all organization accounts
 // pushes them onto a slice of interface, then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryPolicies() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryPolicies(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkAccounts(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetPolicies, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.IamSvc{
 			Client: iam.New(sess, &aws.Config{Credentials: cred}),
 		}
-		policies, err := svc.Policies()
+		policies, err := svc.Policies(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Policies for account: %s -> %v", account, err)
 		}
@@ -479,19 +1381,19 @@ func (inv *Inv) queryPolicies() ([]*spreadsheet.Payload, error) {
 		for _, p := range policies {
 			items = append(items, p)
 		}
-		return &spreadsheet.Payload{Static: []string{account}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name}, Items: items}, nil
 	})
 }
 
 // queryUsers ... queries IAM users for all organization accounts
 // pushes them onto a slice of interface, then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryUsers() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryUsers(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkAccounts(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetUsers, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.IamSvc{
 			Client: iam.New(sess, &aws.Config{Credentials: cred}),
 		}
-		users, err := svc.Users()
+		users, err := svc.Users(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Users for account: %s -> %v", account, err)
 		}
@@ -499,17 +1401,17 @@ func (inv *Inv) queryUsers() ([]*spreadsheet.Payload, error) {
 		for _, u := range users {
 			items = append(items, u)
 		}
-		return &spreadsheet.Payload{Static: []string{account}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name}, Items: items}, nil
 	})
 }
 
 // queryBuckets ... queries S3 buckets for all organization accounts
 // pushes them onto a slice of interface, then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryBuckets() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryBuckets(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkAccounts(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetBuckets, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := s3.New(sess, &aws.Config{Credentials: cred})
-		buckets, err := helpers.Buckets(svc)
+		buckets, err := helpers.Buckets(ctx, svc)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Buckets for account: %s -> %v", account, err)
 		}
@@ -517,10 +1419,16 @@ func (inv *Inv) queryBuckets() ([]*spreadsheet.Payload, error) {
 		for _, b := range buckets {
 			items = append(items, b)
 		}
-		return &spreadsheet.Payload{Static: []string{account}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name}, Items: items}, nil
 	})
 }
 
+var stsCreator = stsClientCreator
+
+func stsClientCreator(p client.ConfigProvider, cfgs ...*aws.Config) stsiface.STSAPI {
+	return sts.New(p, cfgs...)
+}
+
 var ec2Creator = ec2ClientCreator
 
 func ec2ClientCreator(p client.ConfigProvider, cfgs ...*aws.Config) ec2iface.EC2API {
@@ -530,13 +1438,13 @@ func ec2ClientCreator(p client.ConfigProvider, cfgs ...*aws.Config) ec2iface.EC2
 // queryInstances ... queries EC2 instances for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryInstances() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryInstances(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkAccounts(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetInstances, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.Ec2Svc{
 			Client: ec2Creator(sess, &aws.Config{Credentials: cred}),
 		}
-		instances, err := svc.Instances()
+		instances, err := svc.Instances(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Instances for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -544,20 +1452,20 @@ func (inv *Inv) queryInstances() ([]*spreadsheet.Payload, error) {
 		for _, i := range instances {
 			items = append(items, i)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryImages ... queries Amazon machine images (AMI) for all organization
 // accounts and all sessions/regions in SessionMgr, pushes them onto a slice of
 // interface then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryImages() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryImages(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetImages, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.Ec2Svc{
 			Client: ec2Creator(sess, &aws.Config{Credentials: cred}),
 		}
-		images, err := svc.Images()
+		images, err := svc.Images(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Images for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -565,20 +1473,20 @@ func (inv *Inv) queryImages() ([]*spreadsheet.Payload, error) {
 		for _, i := range images {
 			items = append(items, i)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryVolumes ... queries Elastic Block Storage (EBS) volumes for all
 // organization accounts and all sessions/regions in SessionMgr, pushes them
 // onto a slice of interface then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryVolumes() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryVolumes(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetVolumes, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.Ec2Svc{
 			Client: ec2Creator(sess, &aws.Config{Credentials: cred}),
 		}
-		volumes, err := svc.Volumes()
+		volumes, err := svc.Volumes(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Volumes for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -586,20 +1494,20 @@ func (inv *Inv) queryVolumes() ([]*spreadsheet.Payload, error) {
 		for _, v := range volumes {
 			items = append(items, v)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // querySnapshots ... queries EBS snapshots for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) querySnapshots() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) querySnapshots(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetSnapshots, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.Ec2Svc{
 			Client: ec2Creator(sess, &aws.Config{Credentials: cred}),
 		}
-		snapshots, err := svc.Snapshots()
+		snapshots, err := svc.Snapshots(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Snapshots for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -607,20 +1515,20 @@ func (inv *Inv) querySnapshots() ([]*spreadsheet.Payload, error) {
 		for _, s := range snapshots {
 			items = append(items, s)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryIgws ... queries Igws for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryIgws() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryIgws(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetIgws, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.Ec2Svc{
 			Client: ec2Creator(sess, &aws.Config{Credentials: cred}),
 		}
-		peers, err := svc.Igws()
+		peers, err := svc.Igws(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get VpcPeers for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -628,20 +1536,20 @@ func (inv *Inv) queryIgws() ([]*spreadsheet.Payload, error) {
 		for _, v := range peers {
 			items = append(items, v)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryVpcs ... queries VPCs for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryVpcs() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryVpcs(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetVpcs, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.Ec2Svc{
 			Client: ec2Creator(sess, &aws.Config{Credentials: cred}),
 		}
-		vpcs, err := svc.Vpcs()
+		vpcs, err := svc.Vpcs(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get VPCs for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -649,20 +1557,20 @@ func (inv *Inv) queryVpcs() ([]*spreadsheet.Payload, error) {
 		for _, v := range vpcs {
 			items = append(items, v)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryVpcPeers ... queries VpcPeerss for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryVpcPeers() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryVpcPeers(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetVpcPeers, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.Ec2Svc{
 			Client: ec2Creator(sess, &aws.Config{Credentials: cred}),
 		}
-		peers, err := svc.VpcPeers()
+		peers, err := svc.VpcPeers(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get VpcPeers for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -670,20 +1578,20 @@ func (inv *Inv) queryVpcPeers() ([]*spreadsheet.Payload, error) {
 		for _, v := range peers {
 			items = append(items, v)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // querySubnets ... queries subnets for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) querySubnets() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) querySubnets(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetSubnets, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.Ec2Svc{
 			Client: ec2Creator(sess, &aws.Config{Credentials: cred}),
 		}
-		subnets, err := svc.Subnets()
+		subnets, err := svc.Subnets(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Subnets for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -691,20 +1599,20 @@ func (inv *Inv) querySubnets() ([]*spreadsheet.Payload, error) {
 		for _, s := range subnets {
 			items = append(items, s)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // querySecurityGroups ... queries security groups for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) querySecurityGroups() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) querySecurityGroups(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetSecurityGroups, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.Ec2Svc{
 			Client: ec2Creator(sess, &aws.Config{Credentials: cred}),
 		}
-		groups, err := svc.SecurityGroups()
+		groups, err := svc.SecurityGroups(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Security Groups for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -712,20 +1620,20 @@ func (inv *Inv) querySecurityGroups() ([]*spreadsheet.Payload, error) {
 		for _, g := range groups {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryAddresses ... queries EC2 DescribeAddresses for all organization
 // accounts and all sessions/regions in SessionMgr, pushes them onto a slice of
 // interface then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryAddresses() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryAddresses(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetAddresses, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.Ec2Svc{
 			Client: ec2Creator(sess, &aws.Config{Credentials: cred}),
 		}
-		addresses, err := svc.Addresses()
+		addresses, err := svc.Addresses(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get EC2 Addresses for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -733,20 +1641,20 @@ func (inv *Inv) queryAddresses() ([]*spreadsheet.Payload, error) {
 		for _, g := range addresses {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryKeyPairs ... queries EC2 DescribeKeyPairs for all organization
 // accounts and all sessions/regions in SessionMgr, pushes them onto a slice of
 // interface then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryKeyPairs() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryKeyPairs(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetKeyPairs, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.Ec2Svc{
 			Client: ec2Creator(sess, &aws.Config{Credentials: cred}),
 		}
-		keyPairs, err := svc.KeyPairs()
+		keyPairs, err := svc.KeyPairs(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get EC2 KeyPairs for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -754,18 +1662,18 @@ func (inv *Inv) queryKeyPairs() ([]*spreadsheet.Payload, error) {
 		for _, g := range keyPairs {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryStacks ... queries CloudFormation Stacks for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryStacks() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryStacks(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetStacks, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := cloudformation.New(sess, &aws.Config{Credentials: cred})
-		stacks, err := helpers.Stacks(svc)
+		stacks, err := helpers.Stacks(ctx, svc)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get CloudFormation Stacks for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -773,18 +1681,18 @@ func (inv *Inv) queryStacks() ([]*spreadsheet.Payload, error) {
 		for _, g := range stacks {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryAlarms ... queries CloudWatch Alarms for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryAlarms() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryAlarms(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetAlarms, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := cloudwatch.New(sess, &aws.Config{Credentials: cred})
-		alarms, err := helpers.Alarms(svc)
+		alarms, err := helpers.Alarms(ctx, svc)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get CloudWatch Alarms for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -792,18 +1700,18 @@ func (inv *Inv) queryAlarms() ([]*spreadsheet.Payload, error) {
 		for _, g := range alarms {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryConfigRules ... queries Config Rules for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryConfigRules() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryConfigRules(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetConfigRules, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := configservice.New(sess, &aws.Config{Credentials: cred})
-		rules, err := helpers.ConfigRules(svc)
+		rules, err := helpers.ConfigRules(ctx, svc)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Config Rules for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -811,18 +1719,18 @@ func (inv *Inv) queryConfigRules() ([]*spreadsheet.Payload, error) {
 		for _, g := range rules {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryLoadBalancers ... queries ELBv2 Load Balancers for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryLoadBalancers() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryLoadBalancers(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetLoadBalancers, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := elbv2.New(sess, &aws.Config{Credentials: cred})
-		loadBalancers, err := helpers.LoadBalancers(svc)
+		loadBalancers, err := helpers.LoadBalancers(ctx, svc)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get ELBv2 Load Balancers for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -830,7 +1738,7 @@ func (inv *Inv) queryLoadBalancers() ([]*spreadsheet.Payload, error) {
 		for _, g := range loadBalancers {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
@@ -843,11 +1751,11 @@ func glacierClientCreator(p client.ConfigProvider, cfgs ...*aws.Config) glacieri
 // queryVaults ... queries Glacier Vaults for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryVaults() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryVaults(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetVaults, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := &helpers.GlacierSvc{Client: glacierCreator(sess, &aws.Config{Credentials: cred})}
-		vaults, err := svc.Vaults()
+		vaults, err := svc.Vaults(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Glacier Vaults for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -855,18 +1763,18 @@ func (inv *Inv) queryVaults() ([]*spreadsheet.Payload, error) {
 		for _, g := range vaults {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, aws.StringValue(sess.Config.Region)}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, aws.StringValue(sess.Config.Region)}, Items: items}, nil
 	})
 }
 
 // queryKeys ... queries KMS Keys for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryKeys() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryKeys(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetKeys, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := kms.New(sess, &aws.Config{Credentials: cred})
-		keys, err := helpers.Keys(svc)
+		keys, err := helpers.Keys(ctx, svc)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get KMS Keys for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -874,20 +1782,20 @@ func (inv *Inv) queryKeys() ([]*spreadsheet.Payload, error) {
 		for _, g := range keys {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryDBInstances ... queries RDS DBInstances for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryDBInstances() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryDBInstances(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetDBInstances, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.RDSSvc{
 			Client: rds.New(sess, &aws.Config{Credentials: cred}),
 		}
-		instances, err := svc.DBInstances()
+		instances, err := svc.DBInstances(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get RDS DBInstances for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -895,20 +1803,20 @@ func (inv *Inv) queryDBInstances() ([]*spreadsheet.Payload, error) {
 		for _, g := range instances {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryDBSnapshots ... queries RDS DBSnapshots for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryDBSnapshots() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryDBSnapshots(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetDBSnapshots, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.RDSSvc{
 			Client: rds.New(sess, &aws.Config{Credentials: cred}),
 		}
-		snapshots, err := svc.DBSnapshots()
+		snapshots, err := svc.DBSnapshots(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get RDS DBSnapshots for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -916,20 +1824,20 @@ func (inv *Inv) queryDBSnapshots() ([]*spreadsheet.Payload, error) {
 		for _, g := range snapshots {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // querySecrets ... queries SecretsManager Secrets for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) querySecrets() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) querySecrets(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetSecrets, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := helpers.SecretsManagerSvc{
 			Client: secretsmanager.New(sess, &aws.Config{Credentials: cred}),
 		}
-		secrets, err := svc.Secrets()
+		secrets, err := svc.Secrets(ctx)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get Secrets for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -937,18 +1845,18 @@ func (inv *Inv) querySecrets() ([]*spreadsheet.Payload, error) {
 		for _, g := range secrets {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // querySubscriptions ... queries SNS Subscriptions for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) querySubscriptions() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) querySubscriptions(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetSubscriptions, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := sns.New(sess, &aws.Config{Credentials: cred})
-		subscriptions, err := helpers.Subscriptions(svc)
+		subscriptions, err := helpers.Subscriptions(ctx, svc)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get SNS Subscriptions for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -956,18 +1864,18 @@ func (inv *Inv) querySubscriptions() ([]*spreadsheet.Payload, error) {
 		for _, g := range subscriptions {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryTopics ... queries SNS Topics for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryTopics() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryTopics(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetTopics, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := sns.New(sess, &aws.Config{Credentials: cred})
-		topics, err := helpers.Topics(svc)
+		topics, err := helpers.Topics(ctx, svc)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get SNS Topics for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -975,18 +1883,18 @@ func (inv *Inv) queryTopics() ([]*spreadsheet.Payload, error) {
 		for _, g := range topics {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
 
 // queryParameters ... queries SSM Parameter stores for all organization accounts and
 // all sessions/regions in SessionMgr, pushes them onto a slice of interface
 // then returns a slice of *spreadsheet.Payload
-func (inv *Inv) queryParameters() ([]*spreadsheet.Payload, error) {
+func (inv *Inv) queryParameters(ctx context.Context) ([]*spreadsheet.Payload, error) {
 	defer logDuration()()
-	return inv.walkSessions(func(account string, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	return inv.walk(ctx, helpers.SheetParameters, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		svc := ssm.New(sess, &aws.Config{Credentials: cred})
-		parameters, err := helpers.Parameters(svc)
+		parameters, err := helpers.Parameters(ctx, svc)
 		if err != nil {
 			return nil, newQueryErrorf(err, "failed to get SSM Parameters for account: %s, region: %s -> %v", account, *sess.Config.Region, err)
 		}
@@ -994,6 +1902,6 @@ func (inv *Inv) queryParameters() ([]*spreadsheet.Payload, error) {
 		for _, g := range parameters {
 			items = append(items, g)
 		}
-		return &spreadsheet.Payload{Static: []string{account, *sess.Config.Region}, Items: items}, nil
+		return &spreadsheet.Payload{Static: []string{account.ID, account.Name, *sess.Config.Region}, Items: items}, nil
 	})
 }
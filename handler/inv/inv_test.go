@@ -1,29 +1,45 @@
 package inv
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/GSA/grace-inventory/handler/helpers"
+	"github.com/GSA/grace-inventory/handler/helpers/accounts"
 	"github.com/GSA/grace-inventory/handler/helpers/credmgr"
+	"github.com/GSA/grace-inventory/handler/helpers/scheduler"
 	"github.com/GSA/grace-inventory/handler/helpers/sessionmgr"
 	"github.com/GSA/grace-inventory/handler/spreadsheet"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/awstesting/mock"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/glacier"
 	"github.com/aws/aws-sdk-go/service/glacier/glacieriface"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/google/go-cmp/cmp"
+	"github.com/tealeg/xlsx"
 	"gotest.tools/v3/assert"
 )
 
@@ -34,12 +50,20 @@ import (
 type mockStsClient struct {
 	stsiface.STSAPI
 	Response sts.GetCallerIdentityOutput
+	Err      error
 }
 
 func (m *mockStsClient) GetCallerIdentity(*sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
 	return &m.Response, nil
 }
 
+func (m *mockStsClient) GetCallerIdentityWithContext(aws.Context, *sts.GetCallerIdentityInput, ...request.Option) (*sts.GetCallerIdentityOutput, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return &m.Response, nil
+}
+
 func mockNewSession(cfgs ...*aws.Config) (*session.Session, error) {
 	// server is the mock server that simply writes a 200 status back to the client
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -79,6 +103,14 @@ func TestIsKnownError(t *testing.T) {
 			in:       newQueryErrorf(awserr.New("AccessDenied", "test", err), "%s", "test"),
 			expected: true,
 		},
+		"throttling is retried, not known": {
+			in:       newQueryErrorf(awserr.New("ThrottlingException", "test", err), "%s", "test"),
+			expected: false,
+		},
+		"exhausted retries": {
+			in:       &scheduler.RetryError{Err: newQueryErrorf(awserr.New("ThrottlingException", "test", err), "%s", "test")},
+			expected: true,
+		},
 	}
 	for name, tc := range tt {
 		tc := tc
@@ -94,7 +126,6 @@ func TestIsKnownError(t *testing.T) {
 func TestNew(t *testing.T) {
 	tt := map[string]struct {
 		env         map[string]string
-		expected    Inv
 		expectedErr string
 	}{
 		"environment variables not set": {
@@ -108,6 +139,15 @@ func TestNew(t *testing.T) {
 			},
 			expectedErr: "NoCredentialProviders: no valid providers in chain. Deprecated.\n\tFor verbose messaging see aws.Config.CredentialsChainVerboseErrors",
 		},
+		"unsupported output format": {
+			env: map[string]string{
+				"s3_bucket":      "test",
+				"kms_key_id":     "test",
+				"regions":        "us-east-1",
+				"output_formats": "xlsx,pdf",
+			},
+			expectedErr: `unsupported output format "pdf"`,
+		},
 		"happy path": {
 			env: map[string]string{
 				"s3_bucket":  "test",
@@ -138,7 +178,7 @@ func TestNew(t *testing.T) {
 				}
 			}
 
-			actual, err := New()
+			actual, err := New(context.Background())
 
 			envBucket := os.Getenv("s3_bucket")
 			envKmsKey := os.Getenv("kms_key_id")
@@ -154,8 +194,10 @@ func TestNew(t *testing.T) {
 
 			if tc.expectedErr == "" {
 				assert.NilError(t, err)
-				assert.Equal(t, actual.bucketID, envBucket)
-				assert.Equal(t, actual.kmsKeyID, envKmsKey)
+				dest, ok := actual.destination.(*s3Destination)
+				assert.Assert(t, ok)
+				assert.Equal(t, dest.bucketID, envBucket)
+				assert.Equal(t, dest.kmsKeyID, envKmsKey)
 			} else {
 				assert.ErrorContains(t, err, tc.expectedErr)
 			}
@@ -163,6 +205,474 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestSheetNames(t *testing.T) {
+	tt := map[string]struct {
+		in       []string
+		expected []string
+	}{
+		"none":   {nil, DefaultSheets},
+		"empty":  {[]string{""}, DefaultSheets},
+		"one":    {[]string{"Buckets"}, []string{"Accounts", "Buckets", "Errors"}},
+		"two":    {[]string{"Buckets", "Groups"}, []string{"Accounts", "Buckets", "Groups", "Errors"}},
+		"mix":    {[]string{"Buckets", "Accounts", "Groups"}, []string{"Accounts", "Buckets", "Groups", "Errors"}},
+		"errors": {[]string{"Errors", "Buckets"}, []string{"Accounts", "Buckets", "Errors"}},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cfg := &Config{Sheets: tc.in}
+			assert.DeepEqual(t, tc.expected, cfg.SheetNames())
+		})
+	}
+}
+
+func TestOverride(t *testing.T) {
+	base := func() *Config {
+		return &Config{
+			Sheets:       []string{"Buckets"},
+			Regions:      []string{"us-east-1", "us-west-2"},
+			AccountsInfo: "self",
+			OrgUnits:     []string{"ou-1"},
+			OutputName:   "grace_inventory",
+		}
+	}
+	tt := map[string]struct {
+		event    string
+		expected func(*Config)
+	}{
+		"scheduled event": {
+			event:    `{"version":"0","detail-type":"Scheduled Event","source":"aws.events","region":"us-east-2","resources":[],"detail":{}}`,
+			expected: func(*Config) {},
+		},
+		"empty": {event: `{}`, expected: func(*Config) {}},
+		"targeted": {
+			event: `{"sheets":["Instances"],"regions":["us-east-2"],"accounts_info":"111111111111","organizational_units":["ou-2"],"output_name":"incident"}`,
+			expected: func(cfg *Config) {
+				cfg.Sheets = []string{"Instances"}
+				cfg.Regions = []string{"us-east-2"}
+				cfg.AccountsInfo = "111111111111"
+				cfg.OrgUnits = []string{"ou-2"}
+				cfg.OutputName = "incident"
+			},
+		},
+		"narrowed": {
+			event: `{"regions":["us-east-2"]}`,
+			expected: func(cfg *Config) {
+				cfg.Regions = []string{"us-east-2"}
+				cfg.scopeOverride = "regions"
+			},
+		},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var o Overrides
+			assert.NilError(t, json.Unmarshal([]byte(tc.event), &o))
+			actual := base()
+			actual.Override(&o)
+			expected := base()
+			tc.expected(expected)
+			assert.DeepEqual(t, expected, actual, cmp.AllowUnexported(Config{}))
+		})
+	}
+	cfg := base()
+	cfg.Override(nil)
+	assert.DeepEqual(t, base(), cfg, cmp.AllowUnexported(Config{}))
+}
+
+// an inventory whose scope is overridden must not be compared against the configured inventory
+func TestOverrideDrift(t *testing.T) {
+	tt := map[string]struct {
+		event       string
+		driftReport bool
+		expectedErr string
+	}{
+		"scheduled event": {event: `{}`, driftReport: true},
+		"sheets":          {event: `{"sheets":["Instances"]}`, driftReport: true, expectedErr: "drift_report requires an output_name for inventories overriding sheets"},
+		"accounts":        {event: `{"accounts_info":"111111111111"}`, driftReport: true, expectedErr: "drift_report requires an output_name for inventories overriding accounts_info"},
+		"same name":       {event: `{"regions":["us-east-2"],"output_name":"grace_inventory"}`, driftReport: true, expectedErr: "overriding regions"},
+		"own name":        {event: `{"organizational_units":["ou-ab12-cdef3456"],"output_name":"incident"}`, driftReport: true},
+		"no drift report": {event: `{"sheets":["Instances"]}`},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cfg := &Config{OutputDir: "out", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}, DriftReport: tc.driftReport}
+			var o Overrides
+			assert.NilError(t, json.Unmarshal([]byte(tc.event), &o))
+			cfg.Override(&o)
+			err := cfg.Validate()
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+// the Accounts sheet is chosen by NewWithConfig, so it follows an overridden accounts_info
+func TestAccountsSheet(t *testing.T) {
+	defer registerAccountsSheet("")
+	tt := map[string]struct {
+		event    string
+		expected []string
+	}{
+		"self":          {expected: []string{"Alias", "Id"}},
+		"account IDs":   {event: `{"accounts_info": "111111111111,222222222222"}`, expected: []string{"Alias", "Id"}},
+		"organizations": {event: `{"accounts_info": "s3://bucket/accounts.json"}`, expected: []string{"Name", "Id", "Status", "Email", "JoinedMethod", "JoinedTimestamp", "Arn", "OUPath"}},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cfg := &Config{Destination: DestinationStdout, Regions: []string{"us-east-1"}, OutputFormats: []string{"ndjson"}, AccountsInfo: "self"}
+			if tc.event != "" {
+				var o Overrides
+				assert.NilError(t, json.Unmarshal([]byte(tc.event), &o))
+				cfg.Override(&o)
+			}
+			// creating the sessions may fail without credentials, the sheet is registered before
+			_, _ = NewWithConfig(context.Background(), cfg)
+
+			s := spreadsheet.New("test.xlsx")
+			assert.NilError(t, s.AddSheet(helpers.SheetAccounts))
+			var actual []string
+			for _, c := range s.Sheets[0].Columns[1:] {
+				actual = append(actual, c.FriendlyName)
+			}
+			assert.DeepEqual(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestFilename(t *testing.T) {
+	ts := time.Date(2020, 1, 2, 3, 4, 0, 0, time.UTC)
+	assert.Equal(t, "grace_inventory_2020-01-02-0304.xlsx", (&Config{}).Filename(ts))
+	assert.Equal(t, "incident_2020-01-02-0304.xlsx", (&Config{OutputName: "incident"}).Filename(ts))
+	assert.Equal(t, "grace_inventory_", (&Config{}).ReportPrefix())
+	assert.Equal(t, "incident-42_", (&Config{OutputName: "incident-42"}).ReportPrefix())
+}
+
+// func (inv *Inv) addDrift(ctx context.Context, s *spreadsheet.Spreadsheet) error
+func TestAddDrift(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "incident_2020-01-01-0000.ndjson"), nil, 0600))
+	// newer reports with other names can not be compared against, they are not NDJSON
+	later := time.Now().Add(time.Hour)
+	for _, name := range []string{"grace_inventory_2020-01-01-0000.ndjson", "report_2020-01-01-0000.ndjson"} {
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, name), []byte("not json\n"), 0600))
+		assert.NilError(t, os.Chtimes(filepath.Join(dir, name), later, later))
+	}
+	// the previous report is found by the output name, even if the report name has no underscore
+	cfg := &Config{OutputName: "incident"}
+	for _, name := range []string{"report.xlsx", cfg.Filename(time.Now())} {
+		s := spreadsheet.New(name)
+		inv := &Inv{destination: &localDestination{dir: dir}, reportPrefix: cfg.ReportPrefix()}
+		assert.NilError(t, inv.addDrift(context.Background(), s))
+		assert.Assert(t, inv.drift != nil)
+		assert.NilError(t, s.Close())
+	}
+
+	inv := &Inv{destination: &localDestination{dir: dir}, reportPrefix: (&Config{OutputName: "other"}).ReportPrefix()}
+	assert.ErrorContains(t, inv.addDrift(context.Background(), spreadsheet.New("report.xlsx")), "no previous NDJSON report found")
+}
+
+func TestValidate(t *testing.T) {
+	tt := map[string]struct {
+		cfg         Config
+		expectedErr string
+	}{
+		"bucket":  {cfg: Config{Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}}, expectedErr: `required environment variable "s3_bucket" is not set`},
+		"kms key": {cfg: Config{BucketID: "b", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}}, expectedErr: `required environment variable "kms_key_id" is not set`},
+		"output dir required": {
+			cfg:         Config{Destination: "local", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}},
+			expectedErr: `required environment variable "output_dir" is not set`,
+		},
+		"stdout formats": {
+			cfg:         Config{Destination: "stdout", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx", "csv"}},
+			expectedErr: "the stdout destination supports one output format, got: xlsx,csv",
+		},
+		"stdout drift": {
+			cfg:         Config{Destination: "stdout", Regions: []string{"us-east-1"}, OutputFormats: []string{"ndjson"}, DriftReport: true},
+			expectedErr: "drift_report is not supported by the stdout destination",
+		},
+		"output name": {
+			cfg:         Config{OutputDir: "out", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}, OutputName: "../x"},
+			expectedErr: `output_name may only contain letters, digits, '.', '-' and '_', got: "../x"`,
+		},
+		"regions keyword": {
+			cfg:         Config{OutputDir: "out", Regions: []string{"all", "us-east-1"}, OutputFormats: []string{"xlsx"}},
+			expectedErr: `regions must be either "all", "enabled" or a list of regions, got: all,us-east-1`,
+		},
+		"region pattern": {
+			cfg:         Config{OutputDir: "out", Regions: []string{"enabled"}, OutputFormats: []string{"xlsx"}, RegionExclude: []string{"us-[east"}},
+			expectedErr: `invalid region pattern "us-[east"`,
+		},
+		"organizational unit": {
+			cfg:         Config{OutputDir: "out", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}, OrgUnits: []string{"Workloads"}},
+			expectedErr: `invalid organizational unit "Workloads", expected a root (r-xxxx) or OU (ou-xxxx-xxxxxxxx) ID`,
+		},
+		"account roles": {
+			cfg:         Config{OutputDir: "out", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}, AccountRoles: `{"111111111111": {"DurationSeconds": 60}}`},
+			expectedErr: "invalid assume role settings for account 111111111111",
+		},
+		"account roles id": {
+			cfg:         Config{OutputDir: "out", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}, AccountRoles: `{"dev": {"RoleName": "r"}}`},
+			expectedErr: `invalid account ID "dev" in account_roles`,
+		},
+		"account filter": {
+			cfg:         Config{OutputDir: "out", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}, AccountExclude: []string{"sandbox-*"}},
+			expectedErr: `invalid account exclude selector "sandbox-*", expected <kind>:<value>`,
+		},
+		"role chain": {
+			cfg:         Config{OutputDir: "out", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}, RoleChain: []string{"hub"}},
+			expectedErr: `invalid role chain ARN "hub"`,
+		},
+		"discovery": {cfg: Config{OutputDir: "out", Regions: []string{"all"}, OutputFormats: []string{"xlsx"}, RegionInclude: []string{"us-*"}}},
+		"destination": {
+			cfg:         Config{Destination: "ftp", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}},
+			expectedErr: `unsupported destination "ftp"`,
+		},
+		"stdout":      {cfg: Config{Destination: "STDOUT", Regions: []string{"us-east-1"}, OutputFormats: []string{"ndjson"}}},
+		"regions":     {cfg: Config{OutputDir: "out", OutputFormats: []string{"xlsx"}}, expectedErr: `required environment variable "regions" is not set`},
+		"sheet":       {cfg: Config{OutputDir: "out", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}, Sheets: []string{"Nope"}}, expectedErr: "Nope is not a registered Sheet Type"},
+		"format":      {cfg: Config{OutputDir: "out", Regions: []string{"us-east-1"}, OutputFormats: []string{"pdf"}}, expectedErr: `unsupported output format "pdf"`},
+		"rate limits": {cfg: Config{OutputDir: "out", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}, RateLimits: []string{"ec2"}}, expectedErr: "ec2"},
+		"output dir":  {cfg: Config{OutputDir: "out", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}}},
+		"bucket and kms key": {
+			cfg: Config{BucketID: "b", KmsKeyID: "k", Regions: []string{"us-east-1"}, OutputFormats: []string{"xlsx"}, Sheets: []string{"IGWs"}},
+		},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestParseFormats(t *testing.T) {
+	tt := map[string]struct {
+		in          []string
+		expected    []string
+		expectedErr string
+	}{
+		"xlsx":        {in: []string{"xlsx"}, expected: []string{spreadsheet.FormatXlsx}},
+		"several":     {in: []string{"xlsx", " NDJSON", "xlsx", "csv"}, expected: []string{spreadsheet.FormatXlsx, spreadsheet.FormatNDJSON, spreadsheet.FormatCSV}},
+		"none":        {in: []string{""}, expectedErr: "at least one output format is required"},
+		"unsupported": {in: []string{"pdf"}, expectedErr: `unsupported output format "pdf"`},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			actual, err := parseFormats(tc.in)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestReportName(t *testing.T) {
+	assert.Equal(t, "grace_inventory_2020-01-02-0304.ndjson", reportName("grace_inventory_2020-01-02-0304.xlsx", "ndjson"))
+	assert.Equal(t, "report.ndjson", reportName("report", "ndjson"))
+	assert.Equal(t, "report.zip", reportName("report.xlsx", "zip"))
+}
+
+func TestPreviousReport(t *testing.T) {
+	day := func(d int) *time.Time {
+		ts := time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC)
+		return &ts
+	}
+	objects := []*s3.Object{
+		{Key: aws.String("grace_inventory_2020-01-01-0000.ndjson"), LastModified: day(1)},
+		{Key: aws.String("grace_inventory_2020-01-02-0000.xlsx"), LastModified: day(2)},
+		{Key: aws.String("grace_inventory_2020-01-02-0000.ndjson"), LastModified: day(2)},
+		{Key: aws.String("grace_inventory_2020-01-03-0000.ndjson"), LastModified: day(3)},
+		{Key: aws.String("grace_inventory_dev_2020-01-04-0000.ndjson"), LastModified: day(4)},
+	}
+	tt := map[string]struct {
+		objects  []*s3.Object
+		current  string
+		expected string
+	}{
+		"latest":      {objects: objects, current: "grace_inventory_2020-01-04-0000.ndjson", expected: "grace_inventory_2020-01-03-0000.ndjson"},
+		"not current": {objects: objects, current: "grace_inventory_2020-01-03-0000.ndjson", expected: "grace_inventory_2020-01-02-0000.ndjson"},
+		"no ndjson":   {objects: objects[1:2], current: "grace_inventory_2020-01-04-0000.ndjson", expected: ""},
+		"empty":       {current: "grace_inventory_2020-01-04-0000.ndjson", expected: ""},
+		"other name":  {objects: objects[4:], current: "grace_inventory_2020-01-05-0000.ndjson", expected: ""},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, previousReport(tc.objects, "grace_inventory_", tc.current))
+		})
+	}
+}
+
+func TestSaveLocal(t *testing.T) {
+	dir := t.TempDir()
+	s := spreadsheet.New("grace_inventory_2020-01-01-0000.xlsx", spreadsheet.NewXlsx(), spreadsheet.NewNDJSON())
+	defer s.Close()
+	assert.NilError(t, s.AddSheet(helpers.SheetErrors))
+	dest := &localDestination{dir: filepath.Join(dir, "reports")}
+	inv := &Inv{destination: dest, spreadsheet: s}
+	assert.NilError(t, inv.save(context.Background(), &Summary{}))
+	for _, name := range []string{"grace_inventory_2020-01-01-0000.xlsx", "grace_inventory_2020-01-01-0000.ndjson"} {
+		_, err := os.Stat(filepath.Join(dest.dir, name))
+		assert.NilError(t, err)
+	}
+
+	name, body, err := dest.Previous(context.Background(), "grace_inventory_", "grace_inventory_2020-01-02-0000.ndjson")
+	assert.NilError(t, err)
+	assert.NilError(t, body.Close())
+	assert.Equal(t, filepath.Join(dest.dir, "grace_inventory_2020-01-01-0000.ndjson"), name)
+	_, _, err = dest.Previous(context.Background(), "grace_inventory_", "grace_inventory_2020-01-01-0000.ndjson")
+	assert.ErrorContains(t, err, "no previous NDJSON report found")
+}
+
+func TestSaveWriter(t *testing.T) {
+	nd := spreadsheet.NewNDJSON()
+	s := spreadsheet.New("grace_inventory_2020-01-01-0000.xlsx", nd)
+	defer s.Close()
+	assert.NilError(t, s.AddSheet(helpers.SheetErrors))
+	assert.NilError(t, s.UpdateSheet(helpers.SheetErrors, &spreadsheet.Payload{Items: []interface{}{
+		helpers.NewCollectionError("a", "us-east-1", helpers.SheetVpcs, fmt.Errorf("test")),
+	}}))
+	buf := bytes.Buffer{}
+	inv := &Inv{destination: &writerDestination{w: &buf}, spreadsheet: s}
+	assert.NilError(t, inv.save(context.Background(), &Summary{}))
+	assert.Assert(t, strings.Contains(buf.String(), `"account":"a"`), buf.String())
+	_, _, err := inv.destination.Previous(context.Background(), "grace_inventory_", "")
+	assert.ErrorContains(t, err, "previous reports can not be read from stdout")
+}
+
+func TestUpdateSheet(t *testing.T) {
+	nd := spreadsheet.NewNDJSON()
+	x := spreadsheet.NewXlsx()
+	s := spreadsheet.New("test.xlsx", x, nd)
+	defer s.Close()
+	assert.NilError(t, s.AddSheet(helpers.SheetErrors))
+	assert.NilError(t, s.UpdateSheet(helpers.SheetErrors, &spreadsheet.Payload{Items: []interface{}{
+		helpers.NewCollectionError("a", "us-east-1", helpers.SheetVpcs, fmt.Errorf("test")),
+	}}))
+
+	buf := bytes.Buffer{}
+	assert.NilError(t, nd.Render(&buf))
+	var row map[string]interface{}
+	assert.NilError(t, json.NewDecoder(&buf).Decode(&row))
+	assert.Equal(t, "a", row["account"])
+	assert.Equal(t, "us-east-1", row["region"])
+	assert.Equal(t, helpers.SheetErrors, row["sheet"])
+	assert.Equal(t, "test", row["Message"])
+	assert.NilError(t, x.Render(&bytes.Buffer{}))
+}
+
+func TestBoolColumns(t *testing.T) {
+	tt := map[string]struct {
+		sheet    string
+		item     interface{}
+		column   string
+		expected bool
+	}{
+		"region scanned":     {sheet: helpers.SheetRegions, item: &helpers.AccountRegion{AccountID: "a", Region: "us-east-1", Scanned: true}, column: "Scanned", expected: true},
+		"region not scanned": {sheet: helpers.SheetRegions, item: &helpers.AccountRegion{AccountID: "a", Region: "us-west-1"}, column: "Scanned"},
+		"reachable":          {sheet: helpers.SheetAccountAccess, item: &helpers.AccountAccess{AccountID: "a", Reachable: true, Arn: "arn:aws:sts::a:assumed-role/r/s"}, column: "Reachable", expected: true},
+		"unreachable":        {sheet: helpers.SheetAccountAccess, item: &helpers.AccountAccess{AccountID: "b", Code: "AccessDenied"}, column: "Reachable"},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			nd := spreadsheet.NewNDJSON()
+			s := spreadsheet.New("test.xlsx", nd)
+			defer s.Close()
+			assert.NilError(t, s.AddSheet(tc.sheet))
+			assert.NilError(t, s.UpdateSheet(tc.sheet, &spreadsheet.Payload{Items: []interface{}{tc.item}}))
+
+			buf := bytes.Buffer{}
+			assert.NilError(t, nd.Render(&buf))
+			var row map[string]interface{}
+			assert.NilError(t, json.NewDecoder(&buf).Decode(&row))
+			assert.Equal(t, tc.expected, row[tc.column])
+		})
+	}
+}
+
+// func (inv *Inv) skipRemovals(drift *spreadsheet.Drift, summary *Summary)
+func TestSkipRemovals(t *testing.T) {
+	vpcs := map[string][]string{"a/us-east-1": {"vpc-1"}, "a/us-west-1": {"vpc-2"}, "b/us-east-1": {"vpc-3"}}
+	nd := spreadsheet.NewNDJSON()
+	previous := spreadsheet.New("grace_inventory_2020-01-01-0000.xlsx", nd)
+	defer previous.Close()
+	assert.NilError(t, previous.AddSheet(helpers.SheetVpcs))
+	assert.NilError(t, previous.AddSheet(helpers.SheetRoles))
+	for unit, ids := range vpcs {
+		account, region, _ := strings.Cut(unit, "/")
+		for _, id := range ids {
+			assert.NilError(t, previous.UpdateSheet(helpers.SheetVpcs, &spreadsheet.Payload{Static: []string{account, account, region}, Items: []interface{}{&ec2.Vpc{VpcId: aws.String(id)}}}))
+		}
+	}
+	assert.NilError(t, previous.UpdateSheet(helpers.SheetRoles, &spreadsheet.Payload{Static: []string{"a", "a"}, Items: []interface{}{&iam.Role{RoleId: aws.String("role-1"), Arn: aws.String("arn:aws:iam::a:role/r")}}}))
+	// the Regions sheet has no scope, its rows of every region are checked in the default region
+	assert.NilError(t, previous.AddSheet(helpers.SheetRegions))
+	assert.NilError(t, previous.UpdateSheet(helpers.SheetRegions, &spreadsheet.Payload{Items: []interface{}{
+		&helpers.AccountRegion{AccountID: "a", Region: "us-east-1"},
+		&helpers.AccountRegion{AccountID: "a", Region: "us-west-1"},
+	}}))
+	report := bytes.Buffer{}
+	assert.NilError(t, nd.Render(&report))
+
+	tt := map[string]struct {
+		summary  *Summary
+		expected []string
+	}{
+		"complete": {summary: &Summary{}, expected: []string{"a/us-east-1", "a/us-east-1/vpc-1", "a/us-west-1", "a/us-west-1/vpc-2", "arn:aws:iam::a:role/r", "b/us-east-1/vpc-3"}},
+		"failed": {
+			summary: &Summary{Errors: []*helpers.CollectionError{
+				{Account: "a", Region: "us-west-1", Sheet: helpers.SheetVpcs},
+				{Account: "b", Region: "us-east-1", Sheet: helpers.SheetAccountAccess},
+				{Account: "a", Region: "us-east-1", Sheet: helpers.SheetRoles},
+				{Account: "a", Region: "us-east-1", Sheet: helpers.SheetRegions},
+			}},
+			expected: []string{"a/us-east-1/vpc-1"},
+		},
+		"truncated": {summary: &Summary{Truncated: true}},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			drift, err := spreadsheet.NewDrift(bytes.NewReader(report.Bytes()))
+			assert.NilError(t, err)
+			current := spreadsheet.New("grace_inventory_2020-01-02-0000.xlsx", drift)
+			defer current.Close()
+			assert.NilError(t, current.AddSheet(helpers.SheetVpcs))
+			assert.NilError(t, current.AddSheet(helpers.SheetRoles))
+			assert.NilError(t, current.AddSheet(helpers.SheetRegions))
+
+			(&Inv{}).skipRemovals(drift, tc.summary)
+			out := bytes.Buffer{}
+			assert.NilError(t, drift.Render(&out))
+			f, err := xlsx.OpenBinary(out.Bytes())
+			assert.NilError(t, err)
+			var removed []string
+			for _, row := range f.Sheet["Changes"].Rows[1:] {
+				assert.Equal(t, spreadsheet.ChangeRemoved, row.Cells[0].Value)
+				removed = append(removed, row.Cells[2].Value)
+			}
+			sort.Strings(removed)
+			assert.DeepEqual(t, tc.expected, removed)
+		})
+	}
+}
+
 func TestGetCurrentIdentity(t *testing.T) {
 	expected := sts.GetCallerIdentityOutput{
 		Account: aws.String("a"),
@@ -178,28 +688,30 @@ func TestGetCurrentIdentity(t *testing.T) {
 func TestWalkAccounts(t *testing.T) {
 	sessMgr := sessionmgr.New("us-east-1", []string{"us-east-1", "us-west-1"})
 	sessMgr.Sessioner(mockNewSession)
-	err := sessMgr.Init()
+	err := sessMgr.Init(context.Background())
 	if err != nil {
 		t.Fatalf("failed to instantiate session manager: %v", err)
 	}
 
+	// accounts sharing a name must still be walked with their own credentials
 	expected := []*organizations.Account{
-		{Id: aws.String("a"), Name: aws.String("a")},
-		{Id: aws.String("b"), Name: aws.String("b")},
-		{Id: aws.String("c"), Name: aws.String("c")},
+		{Id: aws.String("111111111111"), Name: aws.String("dev")},
+		{Id: aws.String("222222222222"), Name: aws.String("dev")},
+		{Id: aws.String("333333333333"), Name: aws.String("333333333333")},
 	}
 
 	inv := &Inv{
 		accounts:   expected,
 		credMgr:    credmgr.New(mock.Session, "", "", expected),
 		sessionMgr: sessMgr,
+		scheduler:  scheduler.New(1, nil),
 	}
 
 	var actual []*organizations.Account
-	_, err = inv.walkAccounts(func(name string, credentials *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	_, err = inv.walkAccounts(context.Background(), helpers.SheetRoles, func(account accountRef, credentials *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		actual = append(actual, &organizations.Account{
-			Id:   aws.String(name),
-			Name: aws.String(name),
+			Id:   aws.String(account.ID),
+			Name: aws.String(account.Name),
 		})
 		return nil, nil
 	})
@@ -211,7 +723,7 @@ func TestWalkSessions(t *testing.T) {
 	regions := []string{"us-east-1", "us-west-1"}
 	sessMgr := sessionmgr.New("us-east-1", regions)
 	sessMgr.Sessioner(mockNewSession)
-	err := sessMgr.Init()
+	err := sessMgr.Init(context.Background())
 	if err != nil {
 		t.Fatalf("failed to instantiate session manager: %v", err)
 	}
@@ -226,6 +738,7 @@ func TestWalkSessions(t *testing.T) {
 		accounts:   accounts,
 		credMgr:    credmgr.New(mock.Session, "", "", accounts),
 		sessionMgr: sessMgr,
+		scheduler:  scheduler.New(1, nil),
 	}
 
 	// walkSessions calls iterates over each region
@@ -238,11 +751,11 @@ func TestWalkSessions(t *testing.T) {
 	}
 
 	var actual []*organizations.Account
-	_, err = inv.walkSessions(func(name string, credentials *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+	_, err = inv.walkSessions(context.Background(), helpers.SheetVpcs, func(account accountRef, credentials *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
 		t.Logf("region: %s\n", aws.StringValue(sess.Config.Region))
 		actual = append(actual, &organizations.Account{
-			Id:   aws.String(name),
-			Name: aws.String(name),
+			Id:   aws.String(account.ID),
+			Name: aws.String(account.Name),
 		})
 		return nil, nil
 	})
@@ -250,6 +763,314 @@ func TestWalkSessions(t *testing.T) {
 	assert.DeepEqual(t, actual, expected, cmp.AllowUnexported(organizations.Account{}))
 }
 
+// every query of a global sheet runs once per account in the default region,
+// every query of a regional sheet once per account and region
+func TestSheetScopes(t *testing.T) {
+	global := []string{"a/us-east-1", "b/us-east-1", "c/us-east-1"}
+	regional := []string{"a/us-east-1", "a/us-west-1", "b/us-east-1", "b/us-west-1", "c/us-east-1", "c/us-west-1"}
+	expected := map[string][]string{
+		helpers.SheetRoles:          global,
+		helpers.SheetGroups:         global,
+		helpers.SheetPolicies:       global,
+		helpers.SheetUsers:          global,
+		helpers.SheetBuckets:        global,
+		helpers.SheetInstances:      regional,
+		helpers.SheetImages:         regional,
+		helpers.SheetVolumes:        regional,
+		helpers.SheetSnapshots:      regional,
+		helpers.SheetIgws:           regional,
+		helpers.SheetVpcs:           regional,
+		helpers.SheetVpcPeers:       regional,
+		helpers.SheetSubnets:        regional,
+		helpers.SheetSecurityGroups: regional,
+		helpers.SheetAddresses:      regional,
+		helpers.SheetKeyPairs:       regional,
+		helpers.SheetStacks:         regional,
+		helpers.SheetAlarms:         regional,
+		helpers.SheetConfigRules:    regional,
+		helpers.SheetLoadBalancers:  regional,
+		helpers.SheetVaults:         regional,
+		helpers.SheetKeys:           regional,
+		helpers.SheetDBInstances:    regional,
+		helpers.SheetDBSnapshots:    regional,
+		helpers.SheetSecrets:        regional,
+		helpers.SheetSubscriptions:  regional,
+		helpers.SheetTopics:         regional,
+		helpers.SheetParameters:     regional,
+	}
+	// with a canceled context no query is started, every unit is recorded as left out instead
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for name, units := range expected {
+		name, units := name, units
+		t.Run(name, func(t *testing.T) {
+			inv := mockInv(t)
+			query, ok := inv.allQueries()[name]
+			assert.Assert(t, ok, "sheet %q has no query", name)
+			_, err := query(ctx)
+			assert.NilError(t, err)
+			var actual []string
+			for _, e := range inv.summary().Errors {
+				assert.Equal(t, name, e.Sheet)
+				actual = append(actual, e.Account+"/"+e.Region)
+			}
+			assert.DeepEqual(t, units, actual)
+		})
+	}
+}
+
+func TestWalk(t *testing.T) {
+	inv := mockInv(t)
+	tt := map[string]struct {
+		sheet       string
+		expected    []string
+		expectedErr string
+	}{
+		"global": {
+			sheet:    helpers.SheetRoles,
+			expected: []string{"us-east-1", "us-east-1", "us-east-1"},
+		},
+		"regional": {
+			sheet:    helpers.SheetInstances,
+			expected: []string{"us-east-1", "us-west-1", "us-east-1", "us-west-1", "us-east-1", "us-west-1"},
+		},
+		"unknown": {
+			sheet:       "unknown",
+			expectedErr: `sheet "unknown" has no scope`,
+		},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var actual []string
+			_, err := inv.walk(context.Background(), tc.sheet, func(account accountRef, credentials *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+				actual = append(actual, aws.StringValue(sess.Config.Region))
+				return nil, nil
+			})
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.expected, actual)
+		})
+	}
+}
+
+// region filters only apply to regional sheets, global sheets always use the default session
+func TestWalkRegionFilter(t *testing.T) {
+	tt := map[string]struct {
+		regions  []string
+		include  []string
+		exclude  []string
+		regional []string
+	}{
+		"include":   {regions: []string{"us-east-1", "us-west-1"}, include: []string{"us-west-*"}, regional: []string{"us-west-1", "us-west-1", "us-west-1"}},
+		"discovery": {regions: []string{sessionmgr.RegionsEnabled}, exclude: []string{"us-east-*"}},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sessMgr := sessionmgr.New("us-east-1", tc.regions)
+			sessMgr.Sessioner(mockNewSession)
+			sessMgr.Describer(func(ctx context.Context, sess *session.Session, all bool) ([]*ec2.Region, error) {
+				out, err := mockEc2Client{}.DescribeRegionsWithContext(ctx, &ec2.DescribeRegionsInput{})
+				return out.Regions, err
+			})
+			sessMgr.Filter(tc.include, tc.exclude)
+			assert.NilError(t, sessMgr.Init(context.Background()))
+			inv := mockInv(t)
+			inv.sessionMgr = sessMgr
+			inv.defaultRegion = "us-east-1"
+			inv.optInCheck = sessionmgr.IsDiscovery(tc.regions)
+			ec2Creator = mockEc2Creator
+			inv.checkRegions(context.Background())
+
+			walked := func(sheet string) []string {
+				var actual []string
+				_, err := inv.walk(context.Background(), sheet, func(account accountRef, credentials *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+					actual = append(actual, aws.StringValue(sess.Config.Region))
+					return nil, nil
+				})
+				assert.NilError(t, err)
+				return actual
+			}
+			assert.DeepEqual(t, []string{"us-east-1", "us-east-1", "us-east-1"}, walked(helpers.SheetRoles))
+			assert.DeepEqual(t, tc.regional, walked(helpers.SheetInstances))
+			assert.Equal(t, 0, len(inv.summary().Errors))
+		})
+	}
+}
+
+func TestWalkRetry(t *testing.T) {
+	inv := mockInv(t)
+	inv.scheduler.Retry = scheduler.Retry{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	throttled := newQueryErrorf(awserr.New("RequestLimitExceeded", "test", nil), "%s", "test")
+
+	t.Run("queries retry throttled requests", func(t *testing.T) {
+		retries := make(map[string]int)
+		actual, err := inv.walk(context.Background(), helpers.SheetRoles, func(account accountRef, credentials *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+			if r, ok := sess.Config.Retryer.(request.Retryer); ok {
+				retries[account.ID] = r.MaxRetries()
+			}
+			return &spreadsheet.Payload{Static: []string{account.ID}}, nil
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, 3, len(actual))
+		assert.DeepEqual(t, map[string]int{"a": 2, "b": 2, "c": 2}, retries)
+	})
+	t.Run("exhausted retries are collection errors", func(t *testing.T) {
+		actual, err := inv.walk(context.Background(), helpers.SheetRoles, func(account accountRef, credentials *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+			if account.ID == "b" {
+				return nil, throttled
+			}
+			return &spreadsheet.Payload{Static: []string{account.ID}}, nil
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, 2, len(actual))
+		summary := inv.summary()
+		assert.Equal(t, 1, len(summary.Errors))
+		assert.Equal(t, "b", summary.Errors[0].Account)
+		assert.Equal(t, "RequestLimitExceeded", summary.Errors[0].Code)
+	})
+}
+
+func TestCollectionErrors(t *testing.T) {
+	inv := mockInv(t)
+	denied := awserr.New("AccessDenied", "denied", nil)
+	_, err := inv.walk(context.Background(), helpers.SheetVpcs, func(account accountRef, credentials *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+		if account.ID == "c" && aws.StringValue(sess.Config.Region) == "us-west-1" {
+			return nil, newQueryErrorf(denied, "failed to get VPCs for account: %s -> %v", account.ID, denied)
+		}
+		return &spreadsheet.Payload{}, nil
+	})
+	assert.NilError(t, err)
+
+	summary := inv.summary()
+	assert.Equal(t, 3, summary.Accounts)
+	assert.Equal(t, 6, summary.Units)
+	assert.Assert(t, !summary.Complete())
+	assert.Equal(t, 1, len(summary.Errors))
+	e := summary.Errors[0]
+	assert.Equal(t, "c", e.Account)
+	assert.Equal(t, "us-west-1", e.Region)
+	assert.Equal(t, helpers.SheetVpcs, e.Sheet)
+	assert.Equal(t, "AccessDenied", e.Code)
+	assert.Equal(t, "denied", e.Message)
+	assert.Equal(t, "3 accounts, 5 of 6 queries succeeded (83.3%), 1 collection errors", summary.String())
+}
+
+func TestPartialResults(t *testing.T) {
+	inv := mockInv(t)
+	inv.partialResults = true
+	_, err := inv.walk(context.Background(), helpers.SheetVpcs, func(account accountRef, credentials *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+		if account.ID == "b" {
+			return nil, fmt.Errorf("unexpected failure for %s", account.ID)
+		}
+		return &spreadsheet.Payload{}, nil
+	})
+	assert.NilError(t, err)
+
+	summary := inv.summary()
+	assert.Equal(t, 2, len(summary.Errors))
+	assert.Equal(t, 2, summary.Unexpected)
+	assert.Equal(t, "incomplete", summary.Status())
+	assert.Equal(t, "Unknown", summary.Errors[0].Code)
+	assert.Equal(t, "3 accounts, 4 of 6 queries succeeded (66.7%), 2 collection errors, report is incomplete", summary.String())
+}
+
+func TestWalkCanceled(t *testing.T) {
+	inv := mockInv(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	payloads, err := inv.walk(ctx, helpers.SheetVpcs, func(account accountRef, credentials *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+		called = true
+		return &spreadsheet.Payload{}, nil
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, !called)
+	assert.Equal(t, 0, len(payloads))
+
+	summary := inv.summary()
+	assert.Equal(t, 6, len(summary.Errors))
+	assert.Equal(t, 0, summary.Unexpected)
+	assert.Equal(t, "Canceled", summary.Errors[0].Code)
+
+	summary.Truncated = true
+	assert.Equal(t, "truncated", summary.Status())
+	assert.Equal(t, "3 accounts, 0 of 6 queries succeeded (0.0%), 6 collection errors, report is truncated", summary.String())
+}
+
+// func (inv *Inv) withDeadline(ctx context.Context) (context.Context, context.CancelFunc)
+func TestWithDeadline(t *testing.T) {
+	inv := &Inv{deadlineMargin: time.Minute}
+
+	work, cancel := inv.withDeadline(context.Background())
+	defer cancel()
+	_, ok := work.Deadline()
+	assert.Assert(t, !ok)
+
+	deadline := time.Now().Add(10 * time.Minute)
+	parent, cancelParent := context.WithDeadline(context.Background(), deadline)
+	defer cancelParent()
+	work, cancel = inv.withDeadline(parent)
+	defer cancel()
+	actual, ok := work.Deadline()
+	assert.Assert(t, ok)
+	assert.Equal(t, deadline.Add(-time.Minute), actual)
+}
+
+// func (inv *Inv) aggregate(ctx context.Context) error
+func TestAggregate(t *testing.T) {
+	failure := fmt.Errorf("unexpected failure")
+	tt := map[string]struct {
+		partial     bool
+		expectedErr string
+	}{
+		"strict":  {expectedErr: "unexpected failure"},
+		"partial": {partial: true},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			inv := &Inv{
+				partialResults: tc.partial,
+				out:            make(chan interface{}),
+				errc:           make(chan *failed),
+				quit:           make(chan struct{}),
+			}
+			var finished int32
+			ok := func(context.Context) ([]*spreadsheet.Payload, error) {
+				defer atomic.AddInt32(&finished, 1)
+				// give the failing query a head start
+				time.Sleep(10 * time.Millisecond)
+				return []*spreadsheet.Payload{{}, {}}, nil
+			}
+			inv.query(context.Background(), map[string]queryFunc{
+				"Failing": func(context.Context) ([]*spreadsheet.Payload, error) {
+					defer atomic.AddInt32(&finished, 1)
+					return nil, failure
+				},
+				"First":  ok,
+				"Second": ok,
+			})
+
+			err := inv.aggregate(context.Background())
+			// every query go routine has exited by the time aggregate returns
+			assert.Equal(t, int32(3), atomic.LoadInt32(&finished))
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, 0, len(inv.running))
+			assert.Equal(t, 1, inv.summary().Unexpected)
+			assert.Equal(t, "Failing", inv.summary().Errors[0].Sheet)
+		})
+	}
+}
+
 /////////////////////////////////////
 // Mocks for querying EC2 Services //
 /////////////////////////////////////
@@ -258,7 +1079,7 @@ type mockEc2Client struct {
 	ec2iface.EC2API
 }
 
-func (m mockEc2Client) DescribeInstancesPages(in *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+func (m mockEc2Client) DescribeInstancesPagesWithContext(ctx aws.Context, in *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeInstancesOutput{
 		Reservations: []*ec2.Reservation{
 			{
@@ -269,7 +1090,7 @@ func (m mockEc2Client) DescribeInstancesPages(in *ec2.DescribeInstancesInput, fn
 	return nil
 }
 
-func (m mockEc2Client) DescribeImages(in *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+func (m mockEc2Client) DescribeImagesWithContext(ctx aws.Context, in *ec2.DescribeImagesInput, opts ...request.Option) (*ec2.DescribeImagesOutput, error) {
 	return &ec2.DescribeImagesOutput{
 		Images: []*ec2.Image{
 			{ImageId: aws.String("ami-5731123e")},
@@ -277,7 +1098,7 @@ func (m mockEc2Client) DescribeImages(in *ec2.DescribeImagesInput) (*ec2.Describ
 	}, nil
 }
 
-func (m mockEc2Client) DescribeVolumesPages(in *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool) error {
+func (m mockEc2Client) DescribeVolumesPagesWithContext(ctx aws.Context, in *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
 			{VolumeId: aws.String("vol-049df61146c4d7901")},
@@ -285,7 +1106,7 @@ func (m mockEc2Client) DescribeVolumesPages(in *ec2.DescribeVolumesInput, fn fun
 	return nil
 }
 
-func (m mockEc2Client) DescribeSnapshotsPages(in *ec2.DescribeSnapshotsInput, fn func(*ec2.DescribeSnapshotsOutput, bool) bool) error {
+func (m mockEc2Client) DescribeSnapshotsPagesWithContext(ctx aws.Context, in *ec2.DescribeSnapshotsInput, fn func(*ec2.DescribeSnapshotsOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeSnapshotsOutput{
 		Snapshots: []*ec2.Snapshot{
 			{SnapshotId: aws.String("snap-1234567890abcdef0")},
@@ -293,7 +1114,7 @@ func (m mockEc2Client) DescribeSnapshotsPages(in *ec2.DescribeSnapshotsInput, fn
 	return nil
 }
 
-func (m mockEc2Client) DescribeVpcsPages(in *ec2.DescribeVpcsInput, fn func(*ec2.DescribeVpcsOutput, bool) bool) error {
+func (m mockEc2Client) DescribeVpcsPagesWithContext(ctx aws.Context, in *ec2.DescribeVpcsInput, fn func(*ec2.DescribeVpcsOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeVpcsOutput{
 		Vpcs: []*ec2.Vpc{
 			{VpcId: aws.String("vpc-0e9801d129EXAMPLE")},
@@ -301,7 +1122,7 @@ func (m mockEc2Client) DescribeVpcsPages(in *ec2.DescribeVpcsInput, fn func(*ec2
 	return nil
 }
 
-func (m mockEc2Client) DescribeSubnetsPages(in *ec2.DescribeSubnetsInput, fn func(*ec2.DescribeSubnetsOutput, bool) bool) error {
+func (m mockEc2Client) DescribeSubnetsPagesWithContext(ctx aws.Context, in *ec2.DescribeSubnetsInput, fn func(*ec2.DescribeSubnetsOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeSubnetsOutput{
 		Subnets: []*ec2.Subnet{
 			{SubnetId: aws.String("subnet-0bb1c79de3EXAMPLE")},
@@ -309,7 +1130,7 @@ func (m mockEc2Client) DescribeSubnetsPages(in *ec2.DescribeSubnetsInput, fn fun
 	return nil
 }
 
-func (m mockEc2Client) DescribeSecurityGroupsPages(in *ec2.DescribeSecurityGroupsInput, fn func(*ec2.DescribeSecurityGroupsOutput, bool) bool) error {
+func (m mockEc2Client) DescribeSecurityGroupsPagesWithContext(ctx aws.Context, in *ec2.DescribeSecurityGroupsInput, fn func(*ec2.DescribeSecurityGroupsOutput, bool) bool, opts ...request.Option) error {
 	fn(&ec2.DescribeSecurityGroupsOutput{
 		SecurityGroups: []*ec2.SecurityGroup{
 			{GroupId: aws.String("sg-903004f8")},
@@ -317,7 +1138,7 @@ func (m mockEc2Client) DescribeSecurityGroupsPages(in *ec2.DescribeSecurityGroup
 	return nil
 }
 
-func (m mockEc2Client) DescribeAddresses(in *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+func (m mockEc2Client) DescribeAddressesWithContext(ctx aws.Context, in *ec2.DescribeAddressesInput, opts ...request.Option) (*ec2.DescribeAddressesOutput, error) {
 	return &ec2.DescribeAddressesOutput{
 		Addresses: []*ec2.Address{
 			{
@@ -330,7 +1151,7 @@ func (m mockEc2Client) DescribeAddresses(in *ec2.DescribeAddressesInput) (*ec2.D
 	}, nil
 }
 
-func (m mockEc2Client) DescribeKeyPairs(in *ec2.DescribeKeyPairsInput) (*ec2.DescribeKeyPairsOutput, error) {
+func (m mockEc2Client) DescribeKeyPairsWithContext(ctx aws.Context, in *ec2.DescribeKeyPairsInput, opts ...request.Option) (*ec2.DescribeKeyPairsOutput, error) {
 	return &ec2.DescribeKeyPairsOutput{
 		KeyPairs: []*ec2.KeyPairInfo{
 			{KeyName: aws.String("test")},
@@ -338,6 +1159,13 @@ func (m mockEc2Client) DescribeKeyPairs(in *ec2.DescribeKeyPairsInput) (*ec2.Des
 	}, nil
 }
 
+func (m mockEc2Client) DescribeRegionsWithContext(ctx aws.Context, in *ec2.DescribeRegionsInput, opts ...request.Option) (*ec2.DescribeRegionsOutput, error) {
+	return &ec2.DescribeRegionsOutput{Regions: []*ec2.Region{
+		{RegionName: aws.String("us-east-1"), OptInStatus: aws.String("opt-in-not-required")},
+		{RegionName: aws.String("us-west-1"), OptInStatus: aws.String("not-opted-in")},
+	}}, nil
+}
+
 func mockEc2Creator(client.ConfigProvider, ...*aws.Config) ec2iface.EC2API {
 	return mockEc2Client{}
 }
@@ -346,7 +1174,7 @@ func mockInv(t *testing.T) *Inv {
 	regions := []string{"us-east-1", "us-west-1"}
 	sessMgr := sessionmgr.New("us-east-1", regions)
 	sessMgr.Sessioner(mockNewSession)
-	err := sessMgr.Init()
+	err := sessMgr.Init(context.Background())
 	if err != nil {
 		t.Fatalf("failed to instantiate session manager: %v", err)
 	}
@@ -361,6 +1189,7 @@ func mockInv(t *testing.T) *Inv {
 		accounts:   accounts,
 		credMgr:    credmgr.New(mock.Session, "", "", accounts),
 		sessionMgr: sessMgr,
+		scheduler:  scheduler.New(1, nil),
 	}
 	return inv
 }
@@ -368,63 +1197,285 @@ func mockInv(t *testing.T) *Inv {
 func TestQueryInstances(t *testing.T) {
 	inv := mockInv(t)
 	ec2Creator = mockEc2Creator
-	_, err := inv.queryInstances()
+	actual, err := inv.queryInstances(context.Background())
 	assert.NilError(t, err)
+
+	// instances are regional, expect one payload per account and region
+	var regions []string
+	for _, p := range actual {
+		regions = append(regions, p.Static[2])
+	}
+	assert.DeepEqual(t, []string{"us-east-1", "us-west-1", "us-east-1", "us-west-1", "us-east-1", "us-west-1"}, regions)
+}
+
+// func (inv *Inv) checkAccess(ctx context.Context)
+func TestCheckAccess(t *testing.T) {
+	inv := mockInv(t)
+	inv.defaultRegion = "us-east-1"
+	ec2Creator = mockEc2Creator
+	// the mocked identity is the account the credentials belong to, account b is denied
+	accountIDs := make(map[*credentials.Credentials]string)
+	for _, id := range []string{"a", "b", "c"} {
+		cred, err := inv.credMgr.Cred(id)
+		assert.NilError(t, err)
+		accountIDs[cred] = id
+	}
+	stsCreator = func(p client.ConfigProvider, cfgs ...*aws.Config) stsiface.STSAPI {
+		id := accountIDs[cfgs[0].Credentials]
+		m := &mockStsClient{Response: sts.GetCallerIdentityOutput{Account: aws.String(id), Arn: aws.String("arn:" + id)}}
+		if id == "b" {
+			m.Err = awserr.New("AccessDenied", "not authorized to perform sts:AssumeRole", nil)
+		}
+		return m
+	}
+	defer func() { stsCreator = stsClientCreator }()
+	inv.checkAccess(context.Background())
+
+	payloads, err := inv.queryAccountAccess(context.Background())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []interface{}{
+		&helpers.AccountAccess{AccountID: "a", AccountName: "a", Reachable: true, Arn: "arn:a"},
+		&helpers.AccountAccess{AccountID: "b", AccountName: "b", Code: "AccessDenied", Message: "not authorized to perform sts:AssumeRole"},
+		&helpers.AccountAccess{AccountID: "c", AccountName: "c", Reachable: true, Arn: "arn:c"},
+	}, payloads[0].Items)
+	summary := inv.summary()
+	assert.Equal(t, 1, len(summary.Errors))
+	assert.Equal(t, helpers.SheetAccountAccess, summary.Errors[0].Sheet)
+	assert.Equal(t, "b", summary.Errors[0].Account)
+	// a denied account is a known error, it does not make the report incomplete
+	assert.Equal(t, 0, summary.Unexpected)
+	assert.Equal(t, "complete", summary.Status())
+
+	// the unreachable account is skipped by the walkers
+	actual, err := inv.queryInstances(context.Background())
+	assert.NilError(t, err)
+	var accounts []string
+	for _, p := range actual {
+		accounts = append(accounts, p.Static[0])
+	}
+	assert.DeepEqual(t, []string{"a", "a", "c", "c"}, accounts)
+}
+
+// func (inv *Inv) checkRegions(ctx context.Context)
+func TestCheckRegions(t *testing.T) {
+	tt := map[string]struct {
+		optInCheck bool
+		settings   map[string]accounts.Settings
+		status     string
+		expected   []string
+	}{
+		"static":    {expected: []string{"us-east-1", "us-west-1", "us-east-1", "us-west-1", "us-east-1", "us-west-1"}},
+		"discovery": {optInCheck: true, status: "opt-in-not-required", expected: []string{"us-east-1", "us-east-1", "us-east-1"}},
+		"account regions": {
+			settings: map[string]accounts.Settings{"b": {Regions: []string{"us-west-1"}}},
+			expected: []string{"us-east-1", "us-west-1", "us-west-1", "us-east-1", "us-west-1"},
+		},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			inv := mockInv(t)
+			inv.defaultRegion = "us-east-1"
+			inv.optInCheck = tc.optInCheck
+			inv.settings = tc.settings
+			ec2Creator = mockEc2Creator
+			inv.checkRegions(context.Background())
+
+			actual, err := inv.queryInstances(context.Background())
+			assert.NilError(t, err)
+			var regions []string
+			for _, p := range actual {
+				regions = append(regions, p.Static[2])
+			}
+			assert.DeepEqual(t, tc.expected, regions)
+
+			// global sheets use the default session, even for accounts not scanning the default region
+			actual, err = inv.walk(context.Background(), helpers.SheetRoles, func(account accountRef, cred *credentials.Credentials, sess *session.Session) (*spreadsheet.Payload, error) {
+				return &spreadsheet.Payload{Static: []string{account.ID, aws.StringValue(sess.Config.Region)}}, nil
+			})
+			assert.NilError(t, err)
+			var global []string
+			for _, p := range actual {
+				global = append(global, p.Static[0]+"/"+p.Static[1])
+			}
+			assert.DeepEqual(t, []string{"a/us-east-1", "b/us-east-1", "c/us-east-1"}, global)
+
+			payloads, err := inv.queryRegions(context.Background())
+			assert.NilError(t, err)
+			assert.Equal(t, 6, len(payloads[0].Items))
+			row := payloads[0].Items[0].(*helpers.AccountRegion)
+			assert.DeepEqual(t, &helpers.AccountRegion{AccountID: "a", AccountName: "a", Region: "us-east-1", OptInStatus: tc.status, Scanned: true}, row)
+			row = payloads[0].Items[1].(*helpers.AccountRegion)
+			assert.Equal(t, !tc.optInCheck, row.Scanned)
+		})
+	}
+}
+
+// func (inv *Inv) chainedSession(sess *session.Session) *session.Session
+func TestChainedSession(t *testing.T) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String("us-east-1")})
+	assert.NilError(t, err)
+	inv := &Inv{}
+	assert.Equal(t, sess, inv.chainedSession(sess))
+
+	inv.roleChain = []string{"arn:aws:iam::111111111111:role/hub"}
+	chained := inv.chainedSession(sess)
+	assert.Assert(t, chained != sess)
+	assert.Assert(t, chained.Config.Credentials != sess.Config.Credentials)
+	// the chain is shared, so its credentials are only assumed once
+	assert.Equal(t, chained, inv.chainedSession(sess))
+}
+
+// func (inv *Inv) roles() map[string]credmgr.Role
+func TestRoles(t *testing.T) {
+	cfg := Config{AccountRoles: `{"111111111111": {"ExternalId": "ext-123", "DurationSeconds": 3600}, "222222222222": {"RoleName": "Other"}}`}
+	configured, err := cfg.Roles()
+	assert.NilError(t, err)
+	inv := &Inv{
+		settings: map[string]accounts.Settings{
+			"111111111111": {RoleName: "InventoryRole", SessionName: "inventory"},
+			"333333333333": {Regions: []string{"us-east-1"}},
+		},
+		accountRoles: configured,
+	}
+	expected := map[string]credmgr.Role{
+		"111111111111": {Name: "InventoryRole", ExternalID: "ext-123", SessionName: "inventory", Duration: time.Hour},
+		"222222222222": {Name: "Other"},
+		"333333333333": {},
+	}
+	assert.DeepEqual(t, expected, inv.roles())
+}
+
+// func (inv *Inv) accountCred(accountID string) (*credentials.Credentials, error)
+func TestAccountCred(t *testing.T) {
+	inv := mockInv(t)
+	inv.mgmtAccount = "111111111111"
+	inv.roleChain = []string{"arn:aws:iam::111111111111:role/hub"}
+	sess, err := inv.sessionMgr.Default()
+	assert.NilError(t, err)
+	chained := inv.chainedSession(sess)
+
+	// the management account uses the credentials at the end of the role chain
+	cred, err := inv.accountCred("111111111111")
+	assert.NilError(t, err)
+	assert.Equal(t, chained.Config.Credentials, cred)
+
+	// other accounts assume their role from it
+	cred, err = inv.accountCred("222222222222")
+	assert.NilError(t, err)
+	assert.Assert(t, cred != chained.Config.Credentials && cred != sess.Config.Credentials)
+	assert.Assert(t, inv.accountsOptions().Cred != nil)
+}
+
+// func (inv *Inv) queryOrganization(ctx context.Context, sheet string, fn func(*accounts.Svc) ([]interface{}, error)) ([]*spreadsheet.Payload, error)
+func TestQueryOrganization(t *testing.T) {
+	denied := awserr.New("AccessDenied", "denied", nil)
+	tt := map[string]struct {
+		query         func(*Inv, context.Context) ([]*spreadsheet.Payload, error)
+		sheet         string
+		err           error
+		expectedItems int
+		expectedCode  string
+		expectedErr   string
+	}{
+		"org units":        {query: (*Inv).queryOrgUnits, sheet: helpers.SheetOrgUnits},
+		"policies":         {query: (*Inv).querySCPs, sheet: helpers.SheetSCPs},
+		"delegated admins": {query: (*Inv).queryDelegatedAdmins, sheet: helpers.SheetDelegatedAdmins},
+		"items":            {sheet: helpers.SheetOrgUnits, expectedItems: 2},
+		"known error":      {sheet: helpers.SheetOrgUnits, err: newQueryErrorf(denied, "failed -> %v", denied), expectedCode: "AccessDenied"},
+		"unexpected error": {sheet: helpers.SheetOrgUnits, err: errors.New("unexpected"), expectedErr: "unexpected"},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("AWS_ACCESS_KEY_ID", "id")
+			t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+			inv := mockInv(t)
+			inv.mgmtAccount = "m"
+			inv.defaultRegion = "us-east-1"
+			query := tc.query
+			if query == nil {
+				query = func(inv *Inv, ctx context.Context) ([]*spreadsheet.Payload, error) {
+					return inv.queryOrganization(ctx, tc.sheet, func(svc *accounts.Svc) ([]interface{}, error) {
+						return make([]interface{}, tc.expectedItems), tc.err
+					})
+				}
+			}
+			payloads, err := query(inv, context.Background())
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+			summary := inv.summary()
+			assert.Equal(t, 1, summary.Units)
+			if tc.expectedCode != "" {
+				assert.Equal(t, 0, len(payloads))
+				assert.Equal(t, 1, len(summary.Errors))
+				e := summary.Errors[0]
+				assert.DeepEqual(t, []string{"m", "us-east-1", tc.sheet, tc.expectedCode}, []string{e.Account, e.Region, e.Sheet, e.Code})
+				return
+			}
+			assert.Equal(t, 0, len(summary.Errors))
+			assert.Equal(t, 1, len(payloads))
+			assert.Equal(t, tc.expectedItems, len(payloads[0].Items))
+		})
+	}
 }
 
 func TestQueryImages(t *testing.T) {
 	inv := mockInv(t)
 	ec2Creator = mockEc2Creator
-	_, err := inv.queryImages()
+	_, err := inv.queryImages(context.Background())
 	assert.NilError(t, err)
 }
 
 func TestQueryVolumes(t *testing.T) {
 	inv := mockInv(t)
 	ec2Creator = mockEc2Creator
-	_, err := inv.queryVolumes()
+	_, err := inv.queryVolumes(context.Background())
 	assert.NilError(t, err)
 }
 
 func TestQuerySnapshots(t *testing.T) {
 	inv := mockInv(t)
 	ec2Creator = mockEc2Creator
-	_, err := inv.querySnapshots()
+	_, err := inv.querySnapshots(context.Background())
 	assert.NilError(t, err)
 }
 
 func TestQueryVpcs(t *testing.T) {
 	inv := mockInv(t)
 	ec2Creator = mockEc2Creator
-	_, err := inv.queryVpcs()
+	_, err := inv.queryVpcs(context.Background())
 	assert.NilError(t, err)
 }
 
 func TestQuerySubnets(t *testing.T) {
 	inv := mockInv(t)
 	ec2Creator = mockEc2Creator
-	_, err := inv.querySubnets()
+	_, err := inv.querySubnets(context.Background())
 	assert.NilError(t, err)
 }
 
 func TestQuerySecurityGroups(t *testing.T) {
 	inv := mockInv(t)
 	ec2Creator = mockEc2Creator
-	_, err := inv.querySecurityGroups()
+	_, err := inv.querySecurityGroups(context.Background())
 	assert.NilError(t, err)
 }
 
 func TestQueryAddresses(t *testing.T) {
 	inv := mockInv(t)
 	ec2Creator = mockEc2Creator
-	_, err := inv.queryAddresses()
+	_, err := inv.queryAddresses(context.Background())
 	assert.NilError(t, err)
 }
 
 func TestQueryKeyPairs(t *testing.T) {
 	inv := mockInv(t)
 	ec2Creator = mockEc2Creator
-	_, err := inv.queryKeyPairs()
+	_, err := inv.queryKeyPairs(context.Background())
 	assert.NilError(t, err)
 }
 
@@ -436,7 +1487,7 @@ type mockGlacierClient struct {
 	glacieriface.GlacierAPI
 }
 
-func (m mockGlacierClient) ListVaultsPages(in *glacier.ListVaultsInput, fn func(*glacier.ListVaultsOutput, bool) bool) error {
+func (m mockGlacierClient) ListVaultsPagesWithContext(ctx aws.Context, in *glacier.ListVaultsInput, fn func(*glacier.ListVaultsOutput, bool) bool, opts ...request.Option) error {
 	fn(&glacier.ListVaultsOutput{
 		VaultList: []*glacier.DescribeVaultOutput{
 			{VaultARN: aws.String("a"), VaultName: aws.String("a")},
@@ -470,7 +1521,7 @@ func TestQueryVaults(t *testing.T) {
 	regions := []string{"us-east-1", "us-west-1"}
 	sessMgr := sessionmgr.New("us-east-1", regions)
 	sessMgr.Sessioner(mockNewSession)
-	err := sessMgr.Init()
+	err := sessMgr.Init(context.Background())
 	if err != nil {
 		t.Fatalf("failed to instantiate session manager: %v", err)
 	}
@@ -485,6 +1536,7 @@ func TestQueryVaults(t *testing.T) {
 		accounts:   accounts,
 		credMgr:    credmgr.New(mock.Session, "", "", accounts),
 		sessionMgr: sessMgr,
+		scheduler:  scheduler.New(1, nil),
 	}
 
 	var expected []*spreadsheet.Payload
@@ -492,6 +1544,7 @@ func TestQueryVaults(t *testing.T) {
 		for j := 0; j < len(regions); j++ {
 			expected = append(expected, &spreadsheet.Payload{
 				Static: []string{
+					aws.StringValue(accounts[i].Id),
 					aws.StringValue(accounts[i].Name),
 					regions[j],
 				},
@@ -501,7 +1554,7 @@ func TestQueryVaults(t *testing.T) {
 	}
 
 	glacierCreator = mockGlacierCreator
-	actual, err := inv.queryVaults()
+	actual, err := inv.queryVaults(context.Background())
 	assert.NilError(t, err)
 	assert.DeepEqual(t, actual, expected, cmp.AllowUnexported(spreadsheet.Payload{}, glacier.DescribeVaultOutput{}))
 }
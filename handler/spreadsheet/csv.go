@@ -0,0 +1,119 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// CSV ... a Sink that renders each sheet to its own CSV file inside a zip archive
+type CSV struct {
+	files  map[string]*csvFile
+	sheets []string
+}
+
+// csvFile ... the rows written to a single sheet
+type csvFile struct {
+	spool *spool
+	w     *csv.Writer
+}
+
+// NewCSV ... returns an empty *CSV
+func NewCSV() *CSV {
+	return &CSV{files: make(map[string]*csvFile)}
+}
+
+// AddSheet ... creates a spool for the sheet's CSV file
+// and writes the header row with the column names
+func (c *CSV) AddSheet(s *Sheet) error {
+	sp, err := newSpool()
+	if err != nil {
+		return err
+	}
+	f := &csvFile{spool: sp, w: csv.NewWriter(sp)}
+	c.files[s.Name] = f
+	c.sheets = append(c.sheets, s.Name)
+	var header []string
+	for _, col := range s.Columns {
+		header = append(header, col.FriendlyName)
+	}
+	return f.w.Write(header)
+}
+
+// WriteRow ... writes a record to the sheet's CSV file
+func (c *CSV) WriteRow(s *Sheet, row Row) error {
+	var record []string
+	for _, f := range row {
+		record = append(record, csvValue(f.Value))
+	}
+	return c.files[s.Name].w.Write(record)
+}
+
+// Render ... writes a zip archive to 'w' holding one <sheet name>.csv
+// file per sheet, in the order they were added
+func (c *CSV) Render(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	for _, name := range c.sheets {
+		f := c.files[name]
+		f.w.Flush()
+		err := f.w.Error()
+		if err != nil {
+			return err
+		}
+		r, err := f.spool.reader()
+		if err != nil {
+			return err
+		}
+		zf, err := zw.Create(name + ".csv")
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(zf, r)
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// Close ... removes the spool of every sheet
+func (c *CSV) Close() error {
+	var first error
+	for _, f := range c.files {
+		err := f.spool.remove()
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Extension ... returns zip, the CSV files are bundled in a zip archive
+func (c *CSV) Extension() string {
+	return "zip"
+}
+
+// ContentType ... returns the Content-Type of a zip archive
+func (c *CSV) ContentType() string {
+	return "application/zip"
+}
+
+// csvValue ... formats the value of a Field, timestamps are written as
+// RFC3339 in UTC, booleans as true or false and nil as an empty string
+func csvValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case time.Time:
+		return val.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// func (c *CSV) Render(w io.Writer) error
+func TestCSVRender(t *testing.T) {
+	// restore the registered sheet types, TestAddSheet expects none
+	defer func(types map[string]SheetFunc) { sheetTypes = types }(sheetTypes)
+	RegisterSheet("first", func() *Sheet {
+		return &Sheet{Name: "First Sheet", Columns: []*Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
+			{FriendlyName: "Name", FieldName: "Name"},
+			{FriendlyName: "Enabled", FieldName: "Enabled"},
+			{FriendlyName: "Size", FieldName: "Size"},
+			{FriendlyName: "Ratio", FieldName: "Ratio"},
+			{FriendlyName: "Created", FieldName: "Created"},
+		}}
+	})
+	RegisterSheet("second", func() *Sheet {
+		return &Sheet{Name: "Second Sheet", Columns: []*Column{{FriendlyName: "Name", FieldName: "Name"}}}
+	})
+	type resource struct {
+		Name    *string
+		Enabled *bool
+		Size    *int64
+		Ratio   float64
+		Created *time.Time
+	}
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("EST", -5*60*60))
+
+	c := NewCSV()
+	ss := New(test0, c)
+	defer ss.Close()
+	for _, name := range []string{"first", "second"} {
+		err := ss.AddSheet(name)
+		if err != nil {
+			t.Fatalf("failed to call AddSheet: %v", err)
+		}
+	}
+	err := ss.UpdateSheet("first", &Payload{
+		Static: []string{"111111111111", "a"},
+		Items: []interface{}{
+			&resource{Name: aws.String("x, y"), Enabled: aws.Bool(false), Size: aws.Int64(3), Ratio: 0.5, Created: &created},
+			&resource{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to call UpdateSheet: %v", err)
+	}
+
+	buf := bytes.Buffer{}
+	err = c.Render(&buf)
+	if err != nil {
+		t.Fatalf("failed to call Render: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to read zip: %v", err)
+	}
+	expected := map[string][][]string{
+		"first.csv": {
+			{"Account Id", "Account Name", "Name", "Enabled", "Size", "Ratio", "Created"},
+			{"111111111111", "a", "x, y", "false", "3", "0.5", "2020-01-02T08:04:05Z"},
+			{"111111111111", "a", "", "", "", "0", ""},
+		},
+		"second.csv": {
+			{"Name"},
+		},
+	}
+	actual := make(map[string][][]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		records, err := csv.NewReader(rc).ReadAll()
+		rc.Close()
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", f.Name, err)
+		}
+		actual[f.Name] = records
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("Render() failed.\nExpected: %v\nGot: %v", expected, actual)
+	}
+}
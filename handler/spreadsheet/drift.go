@@ -0,0 +1,332 @@
+package spreadsheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+)
+
+// Change constants
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// Drift ... a Sink that compares every row against the rows of a previous NDJSON
+// report by the value of the Identity column. Added, removed
+// and modified resources are rendered to the Changes worksheet of an xlsx workbook.
+// The previous report is spooled, only the identity, fingerprint and position of
+// each previous row are held in memory
+type Drift struct {
+	previous *spool
+	pending  map[string][]span
+	index    map[string]map[string]*entry
+	entries  map[string][]*entry
+	sheets   []*Sheet
+	changes  *Xlsx
+	report   *Sheet
+	skipped  []unit
+}
+
+// unit ... the account, region and sheet of a failed query, empty fields match any value
+type unit struct {
+	account string
+	region  string
+	sheet   string
+}
+
+// span ... the position of a row in the previous report
+type span struct {
+	offset int64
+	length int
+}
+
+// entry ... a row of the previous report
+type entry struct {
+	span
+	identity string
+	hash     uint64
+	seen     bool
+}
+
+// NewDrift ... returns a *Drift comparing rows against the NDJSON report read from 'previous'
+func NewDrift(previous io.Reader) (*Drift, error) {
+	sp, err := newSpool()
+	if err != nil {
+		return nil, err
+	}
+	d := &Drift{
+		previous: sp,
+		pending:  make(map[string][]span),
+		index:    make(map[string]map[string]*entry),
+		entries:  make(map[string][]*entry),
+		changes:  NewXlsx(),
+		report: &Sheet{Name: "changes", Title: "Changes", Columns: []*Column{
+			{FriendlyName: "Change"},
+			{FriendlyName: "Sheet"},
+			{FriendlyName: "Identity"},
+			{FriendlyName: AccountIDColumn},
+			{FriendlyName: AccountNameColumn},
+			{FriendlyName: RegionColumn},
+			{FriendlyName: "Column"},
+			{FriendlyName: "Previous"},
+			{FriendlyName: "Current"},
+		}},
+	}
+	err = d.load(previous)
+	if err == nil {
+		err = d.changes.AddSheet(d.report)
+	}
+	if err != nil {
+		_ = d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// load ... copies the previous report to the spool, noting the position of the rows of each sheet
+func (d *Drift) load(previous io.Reader) error {
+	r := bufio.NewReader(previous)
+	var offset int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var head struct {
+				Sheet string `json:"sheet"`
+			}
+			if err := json.Unmarshal(line, &head); err != nil {
+				return fmt.Errorf("failed to read previous report: %v", err)
+			}
+			if _, err := d.previous.Write(line); err != nil {
+				return err
+			}
+			d.pending[head.Sheet] = append(d.pending[head.Sheet], span{offset: offset, length: len(line)})
+			offset += int64(len(line))
+		}
+		if err == io.EOF {
+			return d.previous.Flush()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// AddSheet ... indexes the previous rows of the sheet by identity, sheets
+// without the Identity column can not be compared and are skipped
+func (d *Drift) AddSheet(s *Sheet) error {
+	spans := d.pending[s.Name]
+	delete(d.pending, s.Name)
+	if !s.identified() {
+		return nil
+	}
+	d.sheets = append(d.sheets, s)
+	index := make(map[string]*entry)
+	for _, sp := range spans {
+		values, err := d.read(s, sp)
+		if err != nil {
+			return err
+		}
+		e := &entry{span: sp, identity: identity(s, values), hash: fingerprint(values)}
+		index[e.identity] = e
+		d.entries[s.Name] = append(d.entries[s.Name], e)
+	}
+	d.index[s.Name] = index
+	return nil
+}
+
+// WriteRow ... compares the row against the previous row with the same identity, adding a
+// change for a new identity, or one for every column that differs from the previous row
+func (d *Drift) WriteRow(s *Sheet, row Row) error {
+	index, ok := d.index[s.Name]
+	if !ok {
+		return nil
+	}
+	values := normalize(s, record(s, row))
+	id := identity(s, values)
+	e, ok := index[id]
+	if !ok {
+		return d.change(ChangeAdded, s, id, values, "", "", "")
+	}
+	e.seen = true
+	if e.hash == fingerprint(values) {
+		return nil
+	}
+	previous, err := d.read(s, e.span)
+	if err != nil {
+		return err
+	}
+	for i, c := range s.Columns {
+		if previous[i] == values[i] {
+			continue
+		}
+		err = d.change(ChangeModified, s, id, values, c.FriendlyName, display(previous[i]), display(values[i]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Skip ... stops the previous rows of a failed query from being reported as removed, as
+// they may still exist. The query is the sheet 'sheet' in account 'account' and region
+// 'region', empty values match any sheet, account or region, and rows without a region
+// match any region. Skip("", "", "") reports no removed rows at all, e.g. for a truncated report
+func (d *Drift) Skip(account, region, sheet string) {
+	d.skipped = append(d.skipped, unit{account: account, region: region, sheet: sheet})
+}
+
+// Render ... adds a change for every previous row that was not written during
+// this run, unless its query was skipped, then streams the workbook to 'w'
+func (d *Drift) Render(w io.Writer) error {
+	for _, s := range d.sheets {
+		for _, e := range d.entries[s.Name] {
+			if e.seen {
+				continue
+			}
+			values, err := d.read(s, e.span)
+			if err != nil {
+				return err
+			}
+			if d.isSkipped(s, values) {
+				continue
+			}
+			err = d.change(ChangeRemoved, s, e.identity, values, "", "", "")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return d.changes.Render(w)
+}
+
+// Close ... removes the spooled previous report and changes
+func (d *Drift) Close() error {
+	err := d.previous.remove()
+	if cerr := d.changes.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Extension ... returns changes.xlsx, so the change report is saved next to the report
+func (d *Drift) Extension() string {
+	return "changes.xlsx"
+}
+
+// ContentType ... returns the Content-Type of an xlsx workbook
+func (d *Drift) ContentType() string {
+	return d.changes.ContentType()
+}
+
+// isSkipped ... returns true if the row belongs to a query passed to Skip
+func (d *Drift) isSkipped(s *Sheet, values []string) bool {
+	account, _, region := location(s, values)
+	for _, u := range d.skipped {
+		if (u.sheet == "" || u.sheet == s.Name) &&
+			(u.account == "" || u.account == account) &&
+			(u.region == "" || region == "" || u.region == region) {
+			return true
+		}
+	}
+	return false
+}
+
+// change ... writes a row to the Changes worksheet
+func (d *Drift) change(change string, s *Sheet, id string, values []string, column, previous, current string) error {
+	account, name, region := location(s, values)
+	return d.changes.WriteRow(d.report, Row{
+		{"Change", change},
+		{"Sheet", s.Title},
+		{"Identity", id},
+		{AccountIDColumn, account},
+		{AccountNameColumn, name},
+		{RegionColumn, region},
+		{"Column", column},
+		{"Previous", previous},
+		{"Current", current},
+	})
+}
+
+// location ... returns the account ID, account name and region of a row, if the sheet has those columns
+func location(s *Sheet, values []string) (account string, name string, region string) {
+	for i, c := range s.Columns {
+		switch c.FriendlyName {
+		case AccountIDColumn:
+			account = display(values[i])
+		case AccountNameColumn:
+			name = display(values[i])
+		case RegionColumn:
+			region = display(values[i])
+		}
+	}
+	return account, name, region
+}
+
+// read ... returns the normalized values of a row of the previous report
+func (d *Drift) read(s *Sheet, sp span) ([]string, error) {
+	buf := make([]byte, sp.length)
+	_, err := d.previous.file.ReadAt(buf, sp.offset)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var rec map[string]interface{}
+	err = dec.Decode(&rec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previous report: %v", err)
+	}
+	return normalize(s, rec), nil
+}
+
+// normalize ... returns the JSON encoding of the value of every column of an NDJSON record,
+// so rows written during this run compare equal to the same rows read from a previous report
+func normalize(s *Sheet, rec map[string]interface{}) []string {
+	values := make([]string, len(s.Columns))
+	for i, c := range s.Columns {
+		b, err := json.Marshal(rec[recordKey(c.FriendlyName)])
+		if err != nil {
+			b = []byte("null")
+		}
+		values[i] = string(b)
+	}
+	return values
+}
+
+// identity ... returns the value of the Identity column of a row
+func identity(s *Sheet, values []string) string {
+	for i, c := range s.Columns {
+		if c.Identity {
+			return display(values[i])
+		}
+	}
+	return ""
+}
+
+// fingerprint ... returns a hash of the normalized values of a row
+func fingerprint(values []string) uint64 {
+	h := fnv.New64a()
+	for _, v := range values {
+		_, _ = h.Write([]byte(v))
+		_, _ = h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+// display ... returns a normalized value as shown in the Changes worksheet,
+// strings are unquoted and null is empty
+func display(value string) string {
+	if value == "null" {
+		return ""
+	}
+	var s string
+	if json.Unmarshal([]byte(value), &s) == nil {
+		return s
+	}
+	return value
+}
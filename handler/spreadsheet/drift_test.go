@@ -0,0 +1,203 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/tealeg/xlsx"
+)
+
+// func (d *Drift) Render(w io.Writer) error
+func TestDriftRender(t *testing.T) {
+	// restore the registered sheet types, TestAddSheet expects none
+	defer func(types map[string]SheetFunc) { sheetTypes = types }(sheetTypes)
+	RegisterSheet("volumes", func() *Sheet {
+		return &Sheet{Name: "EBS Volumes", Columns: []*Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
+			{FriendlyName: "Region", FieldName: ""},
+			{FriendlyName: "VolumeId", FieldName: "VolumeId", Key: true},
+			{FriendlyName: "Size", FieldName: "Size"},
+		}}
+	})
+	RegisterSheet("notes", func() *Sheet {
+		return &Sheet{Name: "Notes", Columns: []*Column{{FriendlyName: "Text", FieldName: "VolumeId"}}}
+	})
+	type volume struct {
+		VolumeId *string
+		Size     *int64
+	}
+	static := []string{"111111111111", "dev", "us-east-1"}
+
+	nd := NewNDJSON()
+	previous := New(test0, nd)
+	defer previous.Close()
+	for _, name := range []string{"volumes", "notes"} {
+		if err := previous.AddSheet(name); err != nil {
+			t.Fatalf("failed to call AddSheet: %v", err)
+		}
+	}
+	err := previous.UpdateSheet("volumes", &Payload{Static: static, Items: []interface{}{
+		&volume{VolumeId: aws.String("vol-1"), Size: aws.Int64(1)},
+		&volume{VolumeId: aws.String("vol-2"), Size: aws.Int64(2)},
+		&volume{VolumeId: aws.String("vol-3"), Size: aws.Int64(3)},
+	}})
+	if err != nil {
+		t.Fatalf("failed to call UpdateSheet: %v", err)
+	}
+	err = previous.UpdateSheet("notes", &Payload{Items: []interface{}{&volume{VolumeId: aws.String("old")}}})
+	if err != nil {
+		t.Fatalf("failed to call UpdateSheet: %v", err)
+	}
+	buf := bytes.Buffer{}
+	if err := nd.Render(&buf); err != nil {
+		t.Fatalf("failed to call Render: %v", err)
+	}
+
+	d, err := NewDrift(&buf)
+	if err != nil {
+		t.Fatalf("failed to call NewDrift: %v", err)
+	}
+	current := New(test1, d)
+	defer current.Close()
+	for _, name := range []string{"volumes", "notes"} {
+		if err := current.AddSheet(name); err != nil {
+			t.Fatalf("failed to call AddSheet: %v", err)
+		}
+	}
+	err = current.UpdateSheet("volumes", &Payload{Static: static, Items: []interface{}{
+		&volume{VolumeId: aws.String("vol-1"), Size: aws.Int64(1)},
+		&volume{VolumeId: aws.String("vol-2"), Size: aws.Int64(5)},
+		&volume{VolumeId: aws.String("vol-4"), Size: aws.Int64(4)},
+	}})
+	if err != nil {
+		t.Fatalf("failed to call UpdateSheet: %v", err)
+	}
+	err = current.UpdateSheet("notes", &Payload{Items: []interface{}{&volume{VolumeId: aws.String("new")}}})
+	if err != nil {
+		t.Fatalf("failed to call UpdateSheet: %v", err)
+	}
+
+	out := bytes.Buffer{}
+	if err := d.Render(&out); err != nil {
+		t.Fatalf("failed to call Render: %v", err)
+	}
+	f, err := xlsx.OpenBinary(out.Bytes())
+	if err != nil {
+		t.Fatalf("failed to open workbook: %v", err)
+	}
+	var actual [][]string
+	for _, row := range f.Sheet["Changes"].Rows {
+		var cells []string
+		for _, c := range row.Cells {
+			cells = append(cells, c.Value)
+		}
+		actual = append(actual, cells)
+	}
+	expected := [][]string{
+		{"Change", "Sheet", "Identity", "Account Id", "Account Name", "Region", "Column", "Previous", "Current"},
+		{ChangeModified, "EBS Volumes", "111111111111/us-east-1/vol-2", "111111111111", "dev", "us-east-1", "Size", "2", "5"},
+		{ChangeAdded, "EBS Volumes", "111111111111/us-east-1/vol-4", "111111111111", "dev", "us-east-1", "", "", ""},
+		{ChangeRemoved, "EBS Volumes", "111111111111/us-east-1/vol-3", "111111111111", "dev", "us-east-1", "", "", ""},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("Render() failed.\nExpected: %v\nGot: %v", expected, actual)
+	}
+}
+
+// func NewDrift(previous io.Reader) (*Drift, error)
+func TestNewDrift(t *testing.T) {
+	_, err := NewDrift(bytes.NewBufferString("not json\n"))
+	if err == nil {
+		t.Fatal("NewDrift should fail when the previous report is not NDJSON")
+	}
+	d, err := NewDrift(bytes.NewBufferString(""))
+	if err != nil {
+		t.Fatalf("failed to call NewDrift: %v", err)
+	}
+	defer d.Close()
+	if d.Extension() != "changes.xlsx" {
+		t.Fatalf("Extension invalid, expected: changes.xlsx, got: %s", d.Extension())
+	}
+}
+
+// func (d *Drift) Skip(account, region, sheet string)
+func TestDriftSkip(t *testing.T) {
+	defer func(types map[string]SheetFunc) { sheetTypes = types }(sheetTypes)
+	RegisterSheet("volumes", func() *Sheet {
+		return &Sheet{Name: "EBS Volumes", Columns: []*Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Region", FieldName: ""},
+			{FriendlyName: "VolumeId", FieldName: "VolumeId", Key: true},
+		}}
+	})
+	type volume struct {
+		VolumeId *string
+	}
+	// the previous report has a volume in two regions of one account and in another account
+	nd := NewNDJSON()
+	previous := New(test0, nd)
+	defer previous.Close()
+	if err := previous.AddSheet("volumes"); err != nil {
+		t.Fatalf("failed to call AddSheet: %v", err)
+	}
+	for _, v := range [][]string{{"111111111111", "us-east-1", "vol-1"}, {"111111111111", "us-west-1", "vol-2"}, {"222222222222", "us-east-1", "vol-3"}} {
+		err := previous.UpdateSheet("volumes", &Payload{Static: v[:2], Items: []interface{}{&volume{VolumeId: aws.String(v[2])}}})
+		if err != nil {
+			t.Fatalf("failed to call UpdateSheet: %v", err)
+		}
+	}
+	report := bytes.Buffer{}
+	if err := nd.Render(&report); err != nil {
+		t.Fatalf("failed to call Render: %v", err)
+	}
+
+	tt := map[string]struct {
+		skip     [][3]string
+		expected []string
+	}{
+		"no failures": {expected: []string{"vol-1", "vol-2", "vol-3"}},
+		"region":      {skip: [][3]string{{"111111111111", "us-west-1", "volumes"}}, expected: []string{"vol-1", "vol-3"}},
+		"account":     {skip: [][3]string{{"222222222222", "", ""}}, expected: []string{"vol-1", "vol-2"}},
+		"sheet":       {skip: [][3]string{{"", "", "volumes"}}},
+		"other sheet": {skip: [][3]string{{"111111111111", "us-east-1", "snapshots"}}, expected: []string{"vol-1", "vol-2", "vol-3"}},
+		"truncated":   {skip: [][3]string{{"", "", ""}}},
+	}
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			d, err := NewDrift(bytes.NewReader(report.Bytes()))
+			if err != nil {
+				t.Fatalf("failed to call NewDrift: %v", err)
+			}
+			current := New(test1, d)
+			defer current.Close()
+			if err := current.AddSheet("volumes"); err != nil {
+				t.Fatalf("failed to call AddSheet: %v", err)
+			}
+			for _, s := range tc.skip {
+				d.Skip(s[0], s[1], s[2])
+			}
+			out := bytes.Buffer{}
+			if err := d.Render(&out); err != nil {
+				t.Fatalf("failed to call Render: %v", err)
+			}
+			f, err := xlsx.OpenBinary(out.Bytes())
+			if err != nil {
+				t.Fatalf("failed to open workbook: %v", err)
+			}
+			var removed []string
+			for _, row := range f.Sheet["Changes"].Rows[1:] {
+				if row.Cells[0].Value == ChangeRemoved {
+					removed = append(removed, path.Base(row.Cells[2].Value))
+				}
+			}
+			if !reflect.DeepEqual(tc.expected, removed) {
+				t.Fatalf("Render() failed.\nExpected removed: %v\nGot: %v", tc.expected, removed)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package spreadsheet
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Column name constants, IdentityColumn holds the identity of a row, the static
+// AccountIDColumn and RegionColumn identify a resource along with the Key columns
+const (
+	IdentityColumn    = "Identity"
+	AccountIDColumn   = "Account Id"
+	AccountNameColumn = "Account Name"
+	RegionColumn      = "Region"
+)
+
+// Identity ... returns the value of the Identity column, the stable key of the
+// resource in the row across runs and sheets, or an empty string if the sheet
+// the row belongs to has no identity
+func (r Row) Identity() string {
+	for _, f := range r {
+		if f.Column == IdentityColumn {
+			s, _ := f.Value.(string)
+			return s
+		}
+	}
+	return ""
+}
+
+// identity ... returns the ARN of 'obj' if the sheet has an ARN field holding one,
+// otherwise the values of the Account Id, Region and Key columns of 'row' joined with slashes
+func (s *Sheet) identity(obj interface{}, row Row) string {
+	if s.ARN != "" {
+		val := reflect.Indirect(reflect.ValueOf(obj)).FieldByName(s.ARN)
+		if val.IsValid() {
+			if arn, ok := plain(val.Interface()).(string); ok && strings.HasPrefix(arn, "arn:") {
+				return arn
+			}
+		}
+	}
+	var parts []string
+	for i, c := range s.Columns {
+		if c.Key || (c.FieldName == "" && (c.FriendlyName == AccountIDColumn || c.FriendlyName == RegionColumn)) {
+			parts = append(parts, csvValue(row[i].Value))
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// identifiable ... returns true if the sheet has an ARN field or Key columns to identify its rows
+func (s *Sheet) identifiable() bool {
+	if s.ARN != "" {
+		return true
+	}
+	for _, c := range s.Columns {
+		if c.Key {
+			return true
+		}
+	}
+	return false
+}
+
+// identified ... returns true if the sheet has the Identity column
+func (s *Sheet) identified() bool {
+	for _, c := range s.Columns {
+		if c.Identity {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package spreadsheet
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// func (r Row) Identity() string
+func TestIdentity(t *testing.T) {
+	// restore the registered sheet types, TestAddSheet expects none
+	defer func(types map[string]SheetFunc) { sheetTypes = types }(sheetTypes)
+	RegisterSheet("arn", func() *Sheet {
+		return &Sheet{Name: "Roles", ARN: "Arn", Columns: []*Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
+			{FriendlyName: "RoleId", FieldName: "Id", Key: true},
+		}}
+	})
+	RegisterSheet("key", func() *Sheet {
+		return &Sheet{Name: "Volumes", Columns: []*Column{
+			{FriendlyName: "Account Id", FieldName: ""},
+			{FriendlyName: "Account Name", FieldName: ""},
+			{FriendlyName: "Region", FieldName: ""},
+			{FriendlyName: "VolumeId", FieldName: "Id", Key: true},
+		}}
+	})
+	RegisterSheet("none", func() *Sheet {
+		return &Sheet{Name: "Errors", Columns: []*Column{{FriendlyName: "Id", FieldName: "Id"}}}
+	})
+	type resource struct {
+		Arn *string
+		Id  *string
+	}
+	ss := New(test0)
+	for _, name := range []string{"arn", "key", "none"} {
+		if err := ss.AddSheet(name); err != nil {
+			t.Fatalf("failed to call AddSheet: %v", err)
+		}
+	}
+	tt := map[string]struct {
+		sheet    *Sheet
+		static   []string
+		item     *resource
+		expected Row
+	}{
+		"arn": {
+			sheet:  ss.Sheets[0],
+			static: []string{"111111111111", "dev"},
+			item:   &resource{Arn: aws.String("arn:aws:iam::111111111111:role/a"), Id: aws.String("AROA1")},
+			expected: Row{
+				{IdentityColumn, "arn:aws:iam::111111111111:role/a"},
+				{"Account Id", "111111111111"},
+				{"Account Name", "dev"},
+				{"RoleId", "AROA1"},
+			},
+		},
+		"missing arn": {
+			sheet:  ss.Sheets[0],
+			static: []string{"111111111111", "dev"},
+			item:   &resource{Id: aws.String("AROA1")},
+			expected: Row{
+				{IdentityColumn, "111111111111/AROA1"},
+				{"Account Id", "111111111111"},
+				{"Account Name", "dev"},
+				{"RoleId", "AROA1"},
+			},
+		},
+		"key": {
+			sheet:  ss.Sheets[1],
+			static: []string{"111111111111", "dev", "us-east-1"},
+			item:   &resource{Id: aws.String("vol-1")},
+			expected: Row{
+				{IdentityColumn, "111111111111/us-east-1/vol-1"},
+				{"Account Id", "111111111111"},
+				{"Account Name", "dev"},
+				{"Region", "us-east-1"},
+				{"VolumeId", "vol-1"},
+			},
+		},
+		"none": {
+			sheet:    ss.Sheets[2],
+			item:     &resource{Id: aws.String("x")},
+			expected: Row{{"Id", "x"}},
+		},
+	}
+	for name, tc := range tt {
+		rows := tc.sheet.Rows(&Payload{Static: tc.static, Items: []interface{}{tc.item}})
+		if !reflect.DeepEqual(tc.expected, rows[0]) {
+			t.Fatalf("%s: Rows() failed.\nExpected: %v\nGot: %v", name, tc.expected, rows[0])
+		}
+		id, _ := tc.expected[0].Value.(string)
+		if tc.expected[0].Column != IdentityColumn {
+			id = ""
+		}
+		if rows[0].Identity() != id {
+			t.Fatalf("%s: Identity() failed, expected: %q, got: %q", name, id, rows[0].Identity())
+		}
+	}
+}
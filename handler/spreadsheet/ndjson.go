@@ -0,0 +1,109 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// NDJSON ... a Sink that writes rows as newline delimited JSON, one object per item
+type NDJSON struct {
+	spool *spool
+	enc   *json.Encoder
+}
+
+// NewNDJSON ... returns an empty *NDJSON
+func NewNDJSON() *NDJSON {
+	return &NDJSON{}
+}
+
+// AddSheet ... creates the spool on the first call, nothing is
+// written for a sheet until it has rows
+func (nd *NDJSON) AddSheet(s *Sheet) error {
+	if nd.spool != nil {
+		return nil
+	}
+	sp, err := newSpool()
+	if err != nil {
+		return err
+	}
+	nd.spool = sp
+	nd.enc = json.NewEncoder(sp)
+	return nil
+}
+
+// WriteRow ... writes one object for the row. Each object holds the sheet name,
+// the account and region columns as "account", "account_name" and "region", and every other
+// column keyed by its FriendlyName
+func (nd *NDJSON) WriteRow(s *Sheet, row Row) error {
+	return nd.enc.Encode(record(s, row))
+}
+
+// Render ... copies the rows written to 'w'
+func (nd *NDJSON) Render(w io.Writer) error {
+	if nd.spool == nil {
+		return nil
+	}
+	r, err := nd.spool.reader()
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// Close ... removes the spool
+func (nd *NDJSON) Close() error {
+	if nd.spool == nil {
+		return nil
+	}
+	return nd.spool.remove()
+}
+
+// Extension ... returns ndjson
+func (nd *NDJSON) Extension() string {
+	return "ndjson"
+}
+
+// ContentType ... returns the Content-Type of newline delimited JSON
+func (nd *NDJSON) ContentType() string {
+	return "application/x-ndjson"
+}
+
+// record ... returns the object written to NDJSON for 'row'
+func record(s *Sheet, row Row) map[string]interface{} {
+	r := map[string]interface{}{"sheet": s.Name}
+	for _, key := range recordKeys {
+		r[key] = ""
+	}
+	for _, f := range row {
+		v := f.Value
+		if t, ok := v.(time.Time); ok {
+			v = t.UTC().Format(time.RFC3339)
+		}
+		if key, ok := recordKeys[f.Column]; ok {
+			if v != nil {
+				r[key] = v
+			}
+			continue
+		}
+		r[f.Column] = v
+	}
+	return r
+}
+
+// recordKeys ... the lower case keys the account and region columns are written under,
+// so rows of every sheet can be filtered by account and region
+var recordKeys = map[string]string{
+	AccountIDColumn:   "account",
+	AccountNameColumn: "account_name",
+	RegionColumn:      "region",
+}
+
+// recordKey ... returns the key the value of 'column' is written under
+func recordKey(column string) string {
+	if key, ok := recordKeys[column]; ok {
+		return key
+	}
+	return column
+}
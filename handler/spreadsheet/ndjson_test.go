@@ -0,0 +1,116 @@
+package spreadsheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// func (nd *NDJSON) WriteRow(s *Sheet, row Row) error
+func TestNDJSONWriteRow(t *testing.T) {
+	// restore the registered sheet types, TestAddSheet expects none
+	defer func(types map[string]SheetFunc) { sheetTypes = types }(sheetTypes)
+	sheetName := "ndjson"
+	RegisterSheet(sheetName, func() *Sheet {
+		return &Sheet{
+			Name: sheetName,
+			Columns: []*Column{
+				{FriendlyName: "Account Id", FieldName: ""},
+				{FriendlyName: "Account Name", FieldName: ""},
+				{FriendlyName: "Region", FieldName: ""},
+				{FriendlyName: "Name", FieldName: "Tags"},
+				{FriendlyName: "State", FieldName: "State"},
+				{FriendlyName: "Enabled", FieldName: "Enabled"},
+				{FriendlyName: "Count", FieldName: "Count"},
+				{FriendlyName: "Created", FieldName: "Created"},
+				{FriendlyName: "Missing", FieldName: "Missing"},
+			},
+		}
+	})
+	nd := NewNDJSON()
+	ss := New(test0, nd)
+	defer ss.Close()
+	err := ss.AddSheet(sheetName)
+	if err != nil {
+		t.Fatalf("failed to call AddSheet: %v", err)
+	}
+	type resource struct {
+		Tags    []*ec2.Tag
+		State   *ec2.InstanceState
+		Enabled *bool
+		Count   *int64
+		Created *time.Time
+	}
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	err = ss.UpdateSheet(sheetName, &Payload{
+		Static: []string{"111111111111", "a", "us-east-1"},
+		Items: []interface{}{
+			&resource{
+				Tags:    []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("web")}},
+				State:   &ec2.InstanceState{Name: aws.String("running")},
+				Enabled: aws.Bool(true),
+				Count:   aws.Int64(2),
+				Created: &created,
+			},
+			&resource{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to call UpdateSheet: %v", err)
+	}
+	err = ss.UpdateSheet("not_here", &Payload{Items: []interface{}{&resource{}}})
+	if err != nil {
+		t.Fatalf("failed to call UpdateSheet: %v", err)
+	}
+
+	buf := bytes.Buffer{}
+	err = nd.Render(&buf)
+	if err != nil {
+		t.Fatalf("failed to call Render: %v", err)
+	}
+	var actual []map[string]interface{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var m map[string]interface{}
+		err = json.Unmarshal(scanner.Bytes(), &m)
+		if err != nil {
+			t.Fatalf("failed to unmarshal %q: %v", scanner.Text(), err)
+		}
+		actual = append(actual, m)
+	}
+	expected := []map[string]interface{}{
+		{
+			"sheet":        sheetName,
+			"account":      "111111111111",
+			"account_name": "a",
+			"region":       "us-east-1",
+			"Name":         "web",
+			"State":        "running",
+			"Enabled":      true,
+			"Count":        float64(2),
+			"Created":      "2020-01-02T03:04:05Z",
+			"Missing":      nil,
+		},
+		{
+			"sheet":        sheetName,
+			"account":      "111111111111",
+			"account_name": "a",
+			"region":       "us-east-1",
+			"Name":         "",
+			"State":        nil,
+			"Enabled":      nil,
+			"Count":        nil,
+			"Created":      nil,
+			"Missing":      nil,
+		},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("WriteRow() failed.\nExpected: %v\nGot: %v", expected, actual)
+	}
+}
@@ -0,0 +1,54 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"io"
+)
+
+// Output format constants
+const (
+	FormatXlsx   = "xlsx"
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+)
+
+// Field ... a single value of a row, Column is the FriendlyName of the column
+// and Value is a string, bool, int64, float64, time.Time or nil
+type Field struct {
+	Column string
+	Value  interface{}
+}
+
+// Row ... the fields of a single item, in column order
+type Row []Field
+
+// Sink ... receives the sheets and rows of a report and renders them in one output format.
+// Rows are spooled to temporary files as they are written, so memory use does not grow
+// with the number of rows
+type Sink interface {
+	// AddSheet ... called once for each sheet, before any of its rows are written
+	AddSheet(s *Sheet) error
+	// WriteRow ... called for every item added to the sheet
+	WriteRow(s *Sheet, row Row) error
+	// Render ... streams the report to 'w', called once after every row has been written
+	Render(w io.Writer) error
+	// Close ... removes the temporary files holding the rows
+	Close() error
+	// Extension ... returns the file extension of the rendered report
+	Extension() string
+	// ContentType ... returns the Content-Type of the rendered report
+	ContentType() string
+}
+
+// NewSink ... returns a new Sink for the output format provided
+func NewSink(format string) (Sink, error) {
+	switch format {
+	case FormatXlsx:
+		return NewXlsx(), nil
+	case FormatNDJSON:
+		return NewNDJSON(), nil
+	case FormatCSV:
+		return NewCSV(), nil
+	}
+	return nil, fmt.Errorf("unsupported output format %q", format)
+}
@@ -0,0 +1,89 @@
+package spreadsheet
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+// recorder ... a Sink that records the sheets and rows it receives
+type recorder struct {
+	sheets []string
+	rows   []Row
+}
+
+func (r *recorder) AddSheet(s *Sheet) error {
+	r.sheets = append(r.sheets, s.Name)
+	return nil
+}
+
+func (r *recorder) WriteRow(s *Sheet, row Row) error {
+	r.rows = append(r.rows, row)
+	return nil
+}
+
+func (r *recorder) Render(w io.Writer) error { return nil }
+func (r *recorder) Close() error             { return nil }
+func (r *recorder) Extension() string        { return "test" }
+func (r *recorder) ContentType() string      { return "text/plain" }
+
+// func NewSink(format string) (Sink, error)
+func TestNewSink(t *testing.T) {
+	tests := []struct {
+		format    string
+		extension string
+	}{
+		{FormatXlsx, "xlsx"},
+		{FormatNDJSON, "ndjson"},
+		{FormatCSV, "zip"},
+	}
+	for _, tt := range tests {
+		sink, err := NewSink(tt.format)
+		if err != nil {
+			t.Fatalf("failed to call NewSink(%s): %v", tt.format, err)
+		}
+		if sink.Extension() != tt.extension {
+			t.Fatalf("Extension invalid, expected: %s, got: %s", tt.extension, sink.Extension())
+		}
+	}
+	_, err := NewSink("pdf")
+	if err == nil {
+		t.Fatal("NewSink should fail for an unsupported format")
+	}
+}
+
+// func (ss *Spreadsheet) AddSink(sink Sink) error
+func TestAddSink(t *testing.T) {
+	// restore the registered sheet types, TestAddSheet expects none
+	defer func(types map[string]SheetFunc) { sheetTypes = types }(sheetTypes)
+	RegisterSheet(test1, func() *Sheet {
+		return &Sheet{Name: "Test", Columns: []*Column{{FriendlyName: column0}, {FriendlyName: "name", FieldName: "Name"}}}
+	})
+	first := &recorder{}
+	ss := New(test0, first)
+	err := ss.AddSheet(test1)
+	if err != nil {
+		t.Fatalf("failed to call AddSheet: %v", err)
+	}
+	second := &recorder{}
+	err = ss.AddSink(second)
+	if err != nil {
+		t.Fatalf("failed to call AddSink: %v", err)
+	}
+	if !reflect.DeepEqual([]string{test1}, second.sheets) {
+		t.Fatalf("AddSink should add existing sheets, expected: [%s], got: %v", test1, second.sheets)
+	}
+	err = ss.UpdateSheet(test1, &Payload{Static: []string{"a"}, Items: []interface{}{struct{ Name string }{"b"}}})
+	if err != nil {
+		t.Fatalf("failed to call UpdateSheet: %v", err)
+	}
+	expected := []Row{{{column0, "a"}, {"name", "b"}}}
+	for _, r := range []*recorder{first, second} {
+		if !reflect.DeepEqual(expected, r.rows) {
+			t.Fatalf("UpdateSheet should write to every sink.\nExpected: %v\nGot: %v", expected, r.rows)
+		}
+	}
+	if ss.Sheets[0].Title != "Test" {
+		t.Fatalf("Title invalid, expected: Test, got: %s", ss.Sheets[0].Title)
+	}
+}
@@ -0,0 +1,46 @@
+package spreadsheet
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+// spool ... a temporary file that rows are written to as they arrive,
+// so they are not held in memory until the report is rendered
+type spool struct {
+	*bufio.Writer
+	file *os.File
+}
+
+// newSpool ... creates a spool in the default directory for temporary files
+func newSpool() (*spool, error) {
+	f, err := os.CreateTemp("", "grace-inventory-*")
+	if err != nil {
+		return nil, err
+	}
+	return &spool{Writer: bufio.NewWriter(f), file: f}, nil
+}
+
+// reader ... flushes the buffered writes, then returns a reader
+// over everything written to the spool so far
+func (sp *spool) reader() (io.Reader, error) {
+	err := sp.Flush()
+	if err != nil {
+		return nil, err
+	}
+	info, err := sp.file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return bufio.NewReader(io.NewSectionReader(sp.file, 0, info.Size())), nil
+}
+
+// remove ... closes and deletes the temporary file
+func (sp *spool) remove() error {
+	err := sp.file.Close()
+	if err != nil {
+		return err
+	}
+	return os.Remove(sp.file.Name())
+}
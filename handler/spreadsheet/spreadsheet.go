@@ -1,15 +1,14 @@
 package spreadsheet
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/tealeg/xlsx"
 )
 
 // SheetFunc ... used by RegisterSheet to register
@@ -18,7 +17,7 @@ type SheetFunc func() *Sheet
 
 var sheetTypes map[string]SheetFunc
 
-// Payload ... used by Update and UpdateSheet to populate
+// Payload ... used by Rows and UpdateSheet to populate
 // a sheet with particular datasets. Static is prepended
 // to every row created by Items. Items should be a slice
 // of objects
@@ -38,26 +37,30 @@ func (p *Payload) String() (msg string) {
 
 // Column ... used to describe a column on a sheet
 // if FieldName is empty, the column is considered
-// to be static
+// to be static. Key columns identify the resource of a
+// row, along with the Account Id and Region columns. The
+// Identity column is added by AddSheet, see Sheet.ARN
 type Column struct {
 	FriendlyName string
 	FieldName    string
+	Key          bool
+	Identity     bool
 }
 
-// Spreadsheet ... holds the desired filename and
-// all sheets created by calling 'AddSheet'
+// Spreadsheet ... holds the desired filename, all sheets created by calling
+// 'AddSheet' and the sinks every row is written to
 type Spreadsheet struct {
-	file   *xlsx.File
 	Name   string
 	Sheets []*Sheet
+	Sinks  []Sink
 }
 
 // New ... returns a *Spreadsheet, and sets the filename
-// to the provided 'name'
-func New(name string) *Spreadsheet {
+// to the provided 'name'. Rows are written to every sink provided
+func New(name string, sinks ...Sink) *Spreadsheet {
 	return &Spreadsheet{
-		file: xlsx.NewFile(),
-		Name: name,
+		Name:  name,
+		Sinks: sinks,
 	}
 }
 
@@ -71,27 +74,37 @@ func RegisterSheet(name string, fn SheetFunc) {
 	sheetTypes[name] = fn
 }
 
+// Registered ... returns the names of all registered sheets in alphabetical order
+func Registered() []string {
+	var names []string
+	for name := range sheetTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // AddSheet ... creates a new sheet using the matching SheetFunc
-// given the provided 'name'. Then initializes the sheet by creating
-// the header row and adding the column names
+// given the provided 'name'. Sheets with an ARN or Key columns get
+// the Identity column as their first column. Then adds the sheet to every sink
 func (ss *Spreadsheet) AddSheet(name string) error {
 	if sheetTypes == nil {
 		return errors.New("zero Sheet Types have been registered")
 	}
 	if fn, ok := sheetTypes[name]; ok {
-		var err error
 		s := fn()
-		// add sheet to underlying file with friendlyName
-		s.sheet, err = ss.file.AddSheet(s.Name)
+		// keep the friendlyName as the title
+		s.Title = s.Name
 		// update our local sheet's name with the internal name
 		s.Name = name
-		if err != nil {
-			return err
+		if s.identifiable() {
+			s.Columns = append([]*Column{{FriendlyName: IdentityColumn, Identity: true}}, s.Columns...)
 		}
-		row := s.sheet.AddRow()
-		for _, c := range s.Columns {
-			cell := row.AddCell()
-			cell.Value = c.FriendlyName
+		for _, sink := range ss.Sinks {
+			err := sink.AddSheet(s)
+			if err != nil {
+				return err
+			}
 		}
 		ss.Sheets = append(ss.Sheets, s)
 		return nil
@@ -99,66 +112,103 @@ func (ss *Spreadsheet) AddSheet(name string) error {
 	return fmt.Errorf("%s is not a registered Sheet Type", name)
 }
 
-// UpdateSheet ... finds the sheet matching the given 'name', then calls
-// Update passing the payload provided
-func (ss *Spreadsheet) UpdateSheet(name string, payload *Payload) {
+// AddSink ... adds every sheet already added to the sink provided,
+// then writes all further rows to it
+func (ss *Spreadsheet) AddSink(sink Sink) error {
 	for _, s := range ss.Sheets {
-		if s.Name == name {
-			s.Update(payload)
-			return
+		err := sink.AddSheet(s)
+		if err != nil {
+			return err
 		}
 	}
+	ss.Sinks = append(ss.Sinks, sink)
+	return nil
 }
 
-// Bytes ... creates a bytes.Buffer, saves the underlying xlsx.File
-// to the buffer, then returns the bytes wrapped in a bytes.Reader
-func (ss *Spreadsheet) Bytes() (*bytes.Reader, error) {
-	// Hopefully this works... Create a buffer,
-	// write the document to it, then wrap the bytes in a bytes.Reader
-	buf := bytes.Buffer{}
-	err := ss.file.Write(&buf)
-	if err != nil {
-		return nil, err
+// UpdateSheet ... finds the sheet matching the given 'name', then writes
+// the rows for the payload provided to every sink
+func (ss *Spreadsheet) UpdateSheet(name string, payload *Payload) error {
+	for _, s := range ss.Sheets {
+		if s.Name != name {
+			continue
+		}
+		for _, row := range s.Rows(payload) {
+			for _, sink := range ss.Sinks {
+				err := sink.WriteRow(s, row)
+				if err != nil {
+					return fmt.Errorf("failed to write %s row: %v", name, err)
+				}
+			}
+		}
+		return nil
 	}
-	return bytes.NewReader(buf.Bytes()), nil
+	return nil
 }
 
-// Sheet ... holds a pointer to the underlying xlsx.Sheet, the sheet name,
-// and all of the columns returned by the SheetFunc
+// Close ... closes every sink, removing the temporary files holding the rows
+func (ss *Spreadsheet) Close() error {
+	var first error
+	for _, sink := range ss.Sinks {
+		err := sink.Close()
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Sheet ... holds the sheet name, the title shown to users, the name
+// of the field holding the ARN of each item if it has one, and all
+// of the columns returned by the SheetFunc
 type Sheet struct {
-	sheet   *xlsx.Sheet
 	Name    string
+	Title   string
+	ARN     string
 	Columns []*Column
 }
 
-// Update ... Enumerates over the provided array, adding a new row for each
-// element and prepending each row with the StaticValues
-func (s *Sheet) Update(payload *Payload) {
+// Rows ... returns a Row for each element of the payload's Items, static
+// columns take their values from the payload's Static values in order
+func (s *Sheet) Rows(payload *Payload) []Row {
 	if payload == nil {
-		return
+		return nil
 	}
+	var rows []Row
 	for _, obj := range payload.Items {
-		row := s.sheet.AddRow()
-		for _, s := range payload.Static {
-			cell := row.AddCell()
-			cell.Value = s
-		}
-		for _, c := range s.Columns {
-			if c.FieldName == "" {
-				continue
-			}
-
-			cell := row.AddCell()
-			cell.Value = ""
+		rows = append(rows, s.row(payload.Static, obj))
+	}
+	return rows
+}
 
-			val := reflect.Indirect(reflect.ValueOf(obj)).FieldByName(c.FieldName)
-			// handle nil here instead of inside setCell
-			if !val.IsValid() || (val.Kind() == reflect.Ptr && val.IsNil()) {
-				continue
+// row ... returns the value of every column for 'obj' in column order, static
+// columns take the next value from 'static'. Missing and nil fields are returned as nil.
+// The Identity column is set once every other column has its value
+func (s *Sheet) row(static []string, obj interface{}) Row {
+	row := make(Row, len(s.Columns))
+	for i, c := range s.Columns {
+		row[i].Column = c.FriendlyName
+		if c.Identity {
+			continue
+		}
+		if c.FieldName == "" {
+			if len(static) > 0 {
+				row[i].Value = static[0]
+				static = static[1:]
 			}
-			s.setCell(cell, val.Interface())
+			continue
+		}
+		val := reflect.Indirect(reflect.ValueOf(obj)).FieldByName(c.FieldName)
+		if !val.IsValid() || (val.Kind() == reflect.Ptr && val.IsNil()) {
+			continue
+		}
+		row[i].Value = plain(val.Interface())
+	}
+	for i, c := range s.Columns {
+		if c.Identity {
+			row[i].Value = s.identity(obj, row)
 		}
 	}
+	return row
 }
 
 // getTagName ... loops over tags looking for a Key that matches Name and returns the Value
@@ -172,36 +222,38 @@ func getTagName(tags []*ec2.Tag) string {
 }
 
 // nolint: gocyclo
-// setCell ... sets the value of a cell, after converting it from interface{}
-func (s *Sheet) setCell(cell *xlsx.Cell, val interface{}) {
+// plain ... converts 'val' to the value stored in a Field, returning a string,
+// bool, int64, float64, time.Time or nil for types that are not handled
+func plain(val interface{}) interface{} {
 	switch v := val.(type) {
-	case nil:
-		return
 	case *string:
-		cell.Value = aws.StringValue(v)
+		return aws.StringValue(v)
 	case *bool:
-		cell.SetBool(aws.BoolValue(v))
+		return aws.BoolValue(v)
 	case *int:
-		cell.SetInt(aws.IntValue(v))
+		return int64(aws.IntValue(v))
 	case *int64:
-		cell.SetInt64(aws.Int64Value(v))
+		return aws.Int64Value(v)
 	case *float64:
-		cell.SetFloat(aws.Float64Value(v))
+		return aws.Float64Value(v)
 	case *ec2.InstanceState:
-		cell.Value = aws.StringValue(v.Name)
+		return aws.StringValue(v.Name)
 	case int:
-		cell.SetInt(v)
+		return int64(v)
 	case int64:
-		cell.SetInt64(v)
+		return v
 	case float64:
-		cell.SetFloat(v)
+		return v
+	case bool:
+		return v
 	case string:
-		cell.Value = v
+		return v
 	case time.Time:
-		cell.SetDateTime(v)
+		return v
 	case []*ec2.Tag:
-		cell.Value = getTagName(v)
+		return getTagName(v)
 	case *time.Time:
-		cell.SetDateTime(aws.TimeValue(v))
+		return aws.TimeValue(v)
 	}
+	return nil
 }
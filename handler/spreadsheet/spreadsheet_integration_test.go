@@ -3,7 +3,10 @@
 package spreadsheet
 
 import (
+	"bytes"
 	"testing"
+
+	"github.com/tealeg/xlsx"
 )
 
 const itest, itest0, itest1 = "itest", "itest0", "itest1"
@@ -77,7 +80,9 @@ func TestIntegrationUpdateSheet(t *testing.T) {
 	docName := itest0
 	sheetName := itest1
 	colName := column0
-	s := New(docName)
+	x := NewXlsx()
+	s := New(docName, x)
+	defer s.Close()
 	RegisterSheet(sheetName, func() *Sheet {
 		return &Sheet{
 			Name: sheetName,
@@ -117,7 +122,16 @@ func TestIntegrationUpdateSheet(t *testing.T) {
 		Static: []string{"colval0"},
 		Items:  items,
 	})
-	sheet := s.Sheets[0].sheet
+	buf := bytes.Buffer{}
+	err = x.Render(&buf)
+	if err != nil {
+		t.Fatalf("failed to call Render: %v", err)
+	}
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	if err != nil {
+		t.Fatalf("failed to open workbook: %v", err)
+	}
+	sheet := f.Sheets[0]
 	tests := []struct {
 		row      int
 		cell     int
@@ -142,33 +156,36 @@ func TestIntegrationUpdateSheet(t *testing.T) {
 	}
 }
 
-// func (ss *Spreadsheet) Bytes() (*bytes.Reader, error)
-func TestIntegrationBytes(t *testing.T) {
-	docName := itest0
-	s := New(docName)
-	_, err := s.Bytes()
+// func (x *Xlsx) Render(w io.Writer) error
+func TestIntegrationRender(t *testing.T) {
+	x := NewXlsx()
+	defer x.Close()
+	err := x.Render(&bytes.Buffer{})
 	if err == nil {
-		t.Fatal("Bytes should fail if no worksheet has been added")
+		t.Fatal("Render should fail if no sheet has been added")
 	}
-	_, err = s.file.AddSheet(itest)
+	err = x.AddSheet(&Sheet{Name: itest, Title: itest})
 	if err != nil {
-		t.Fatal("failed to add worksheet to spreadsheet")
+		t.Fatalf("failed to call AddSheet: %v", err)
 	}
-	r, err := s.Bytes()
+	buf := bytes.Buffer{}
+	err = x.Render(&buf)
 	if err != nil {
-		t.Fatalf("failed to get bytes: %v", err)
+		t.Fatalf("failed to call Render: %v", err)
 	}
-	if r.Len() == 0 {
-		t.Fatal("Bytes length invalid, expected: > 0, got: 0")
+	if buf.Len() == 0 {
+		t.Fatal("Render length invalid, expected: > 0, got: 0")
 	}
 }
 
-// func (s *Sheet) Update(payload *Payload)
-func TestIntegrationUpdate(t *testing.T) {
+// func (x *Xlsx) WriteRow(s *Sheet, row Row) error
+func TestIntegrationWriteRow(t *testing.T) {
 	docName := itest0
 	sheetName := itest1
 	colName := column0
-	s := New(docName)
+	x := NewXlsx()
+	s := New(docName, x)
+	defer s.Close()
 	RegisterSheet(sheetName, func() *Sheet {
 		return &Sheet{
 			Name: sheetName,
@@ -204,11 +221,25 @@ func TestIntegrationUpdate(t *testing.T) {
 	for _, o := range objects {
 		items = append(items, o)
 	}
-	s.Sheets[0].Update(&Payload{
+	for _, row := range s.Sheets[0].Rows(&Payload{
 		Static: []string{"colval0"},
 		Items:  items,
-	})
-	sheet := s.Sheets[0].sheet
+	}) {
+		err = x.WriteRow(s.Sheets[0], row)
+		if err != nil {
+			t.Fatalf("failed to call WriteRow: %v", err)
+		}
+	}
+	buf := bytes.Buffer{}
+	err = x.Render(&buf)
+	if err != nil {
+		t.Fatalf("failed to call Render: %v", err)
+	}
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	if err != nil {
+		t.Fatalf("failed to open workbook: %v", err)
+	}
+	sheet := f.Sheets[0]
 	tests := []struct {
 		row      int
 		cell     int
@@ -1,7 +1,11 @@
 package spreadsheet
 
 import (
+	"bytes"
+	"reflect"
 	"testing"
+
+	"github.com/tealeg/xlsx"
 )
 
 const test, test0, test1, column0 = "test", "test0", "test1", "column0"
@@ -72,7 +76,9 @@ func TestUpdateSheet(t *testing.T) {
 	docName := test0
 	sheetName := test1
 	colName := column0
-	s := New(docName)
+	x := NewXlsx()
+	s := New(docName, x)
+	defer s.Close()
 	RegisterSheet(sheetName, func() *Sheet {
 		return &Sheet{
 			Name: sheetName,
@@ -112,7 +118,16 @@ func TestUpdateSheet(t *testing.T) {
 		Static: []string{"colval0"},
 		Items:  items,
 	})
-	sheet := s.Sheets[0].sheet
+	buf := bytes.Buffer{}
+	err = x.Render(&buf)
+	if err != nil {
+		t.Fatalf("failed to call Render: %v", err)
+	}
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	if err != nil {
+		t.Fatalf("failed to open workbook: %v", err)
+	}
+	sheet := f.Sheets[0]
 	tests := []struct {
 		row      int
 		cell     int
@@ -137,29 +152,30 @@ func TestUpdateSheet(t *testing.T) {
 	}
 }
 
-// func (ss *Spreadsheet) Bytes() (*bytes.Reader, error)
-func TestBytes(t *testing.T) {
-	docName := test0
-	s := New(docName)
-	_, err := s.Bytes()
+// func (x *Xlsx) Render(w io.Writer) error
+func TestRender(t *testing.T) {
+	x := NewXlsx()
+	defer x.Close()
+	err := x.Render(&bytes.Buffer{})
 	if err == nil {
-		t.Fatal("Bytes should fail if no worksheet has been added")
+		t.Fatal("Render should fail if no sheet has been added")
 	}
-	_, err = s.file.AddSheet(test)
+	err = x.AddSheet(&Sheet{Name: test, Title: test})
 	if err != nil {
-		t.Fatal("failed to add worksheet to spreadsheet")
+		t.Fatalf("failed to call AddSheet: %v", err)
 	}
-	r, err := s.Bytes()
+	buf := bytes.Buffer{}
+	err = x.Render(&buf)
 	if err != nil {
-		t.Fatalf("failed to get bytes: %v", err)
+		t.Fatalf("failed to call Render: %v", err)
 	}
-	if r.Len() == 0 {
-		t.Fatal("Bytes length invalid, expected: > 0, got: 0")
+	if buf.Len() == 0 {
+		t.Fatal("Render length invalid, expected: > 0, got: 0")
 	}
 }
 
-// func (s *Sheet) Update(payload *Payload)
-func TestUpdate(t *testing.T) {
+// func (s *Sheet) Rows(payload *Payload) []Row
+func TestRows(t *testing.T) {
 	docName := test0
 	sheetName := test1
 	colName := column0
@@ -199,31 +215,19 @@ func TestUpdate(t *testing.T) {
 	for _, o := range objects {
 		items = append(items, o)
 	}
-	s.Sheets[0].Update(&Payload{
+	rows := s.Sheets[0].Rows(&Payload{
 		Static: []string{"colval0"},
 		Items:  items,
 	})
-	sheet := s.Sheets[0].sheet
-	tests := []struct {
-		row      int
-		cell     int
-		expected string
-	}{
-		{0, 0, colName},
-		{0, 1, "name"},
-		{0, 2, "value"},
-		{1, 0, "colval0"},
-		{1, 1, "name0"},
-		{1, 2, "value0"},
-		{2, 1, "name1"},
-		{2, 2, "value1"},
-		{3, 1, "name2"},
-		{3, 2, "value2"},
+	expected := []Row{
+		{{colName, "colval0"}, {"name", "name0"}, {"value", "value0"}},
+		{{colName, "colval0"}, {"name", "name1"}, {"value", "value1"}},
+		{{colName, "colval0"}, {"name", "name2"}, {"value", "value2"}},
 	}
-	for _, tt := range tests {
-		c := sheet.Cell(tt.row, tt.cell)
-		if c.Value != tt.expected {
-			t.Fatalf("Cell(%d, %d) invalid, expected: %s, got: %s", tt.row, tt.cell, tt.expected, c.Value)
-		}
+	if !reflect.DeepEqual(expected, rows) {
+		t.Fatalf("Rows() failed.\nExpected: %v\nGot: %v", expected, rows)
+	}
+	if s.Sheets[0].Rows(nil) != nil {
+		t.Fatal("Rows should return nil for a nil payload")
 	}
 }
@@ -0,0 +1,180 @@
+package spreadsheet
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/tealeg/xlsx"
+)
+
+func init() {
+	// time.Time is the only Field value gob does not know how to decode into an interface{}
+	gob.Register(time.Time{})
+}
+
+// dateTimeStyle ... the style of cells holding a time.Time, matching xlsx.Cell.SetDateTime
+var dateTimeStyle = xlsx.MakeStyle(xlsx.DateTimeFormat_d_m_yy_h_mm,
+	xlsx.DefaultFont(), xlsx.DefaultFill(), xlsx.DefaultAlignment(), xlsx.DefaultBorder())
+
+// Xlsx ... a Sink that renders every sheet to a worksheet of an xlsx workbook. The
+// workbook is streamed one worksheet at a time, so the rows of each sheet are spooled
+// until Render is called
+type Xlsx struct {
+	sheets []*xlsxSheet
+	index  map[string]*xlsxSheet
+}
+
+// xlsxSheet ... the sheet and the gob encoded values of the rows written to it
+type xlsxSheet struct {
+	sheet *Sheet
+	spool *spool
+	enc   *gob.Encoder
+}
+
+// NewXlsx ... returns an empty *Xlsx
+func NewXlsx() *Xlsx {
+	return &Xlsx{index: make(map[string]*xlsxSheet)}
+}
+
+// AddSheet ... creates a spool for the sheet's rows
+func (x *Xlsx) AddSheet(s *Sheet) error {
+	sp, err := newSpool()
+	if err != nil {
+		return err
+	}
+	xs := &xlsxSheet{sheet: s, spool: sp, enc: gob.NewEncoder(sp)}
+	x.sheets = append(x.sheets, xs)
+	x.index[s.Name] = xs
+	return nil
+}
+
+// WriteRow ... writes the values of the row to the sheet's spool
+func (x *Xlsx) WriteRow(s *Sheet, row Row) error {
+	values := make([]interface{}, len(row))
+	for i, f := range row {
+		values[i] = f.Value
+	}
+	return x.index[s.Name].enc.Encode(values)
+}
+
+// Render ... streams the workbook to 'w', adding a worksheet named after the Title
+// of each sheet, with a header row holding the column names followed by its rows
+func (x *Xlsx) Render(w io.Writer) error {
+	if len(x.sheets) == 0 {
+		return errors.New("zero sheets have been added")
+	}
+	sb := xlsx.NewStreamFileBuilder(w)
+	err := sb.AddStreamStyleList([]xlsx.StreamStyle{xlsx.StreamStyleDefaultString, dateTimeStyle})
+	if err != nil {
+		return err
+	}
+	for _, xs := range x.sheets {
+		styles := make([]xlsx.StreamStyle, len(xs.sheet.Columns))
+		for i := range styles {
+			styles[i] = xlsx.StreamStyleDefaultString
+		}
+		err = sb.AddSheetS(xs.sheet.Title, styles)
+		if err != nil {
+			return err
+		}
+	}
+	sf, err := sb.Build()
+	if err != nil {
+		return err
+	}
+	for i, xs := range x.sheets {
+		if i > 0 {
+			err = sf.NextSheet()
+			if err != nil {
+				return err
+			}
+		}
+		err = xs.render(sf)
+		if err != nil {
+			return err
+		}
+	}
+	return sf.Close()
+}
+
+// render ... writes the header row and every spooled row to the current worksheet of 'sf'
+func (xs *xlsxSheet) render(sf *xlsx.StreamFile) error {
+	header := make([]xlsx.StreamCell, len(xs.sheet.Columns))
+	for i, c := range xs.sheet.Columns {
+		header[i] = xlsx.NewStringStreamCell(c.FriendlyName)
+	}
+	err := sf.WriteS(header)
+	if err != nil {
+		return err
+	}
+	r, err := xs.spool.reader()
+	if err != nil {
+		return err
+	}
+	dec := gob.NewDecoder(r)
+	for {
+		var values []interface{}
+		err = dec.Decode(&values)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		cells := make([]xlsx.StreamCell, len(values))
+		for i, v := range values {
+			cells[i] = streamCell(v)
+		}
+		err = sf.WriteS(cells)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Close ... removes the spool of every sheet
+func (x *Xlsx) Close() error {
+	var first error
+	for _, xs := range x.sheets {
+		err := xs.spool.remove()
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Extension ... returns xlsx
+func (x *Xlsx) Extension() string {
+	return "xlsx"
+}
+
+// ContentType ... returns the Content-Type of an xlsx workbook
+func (x *Xlsx) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+// streamCell ... returns the cell for the value of a Field, nil is written as an empty string
+func streamCell(val interface{}) xlsx.StreamCell {
+	switch v := val.(type) {
+	case bool:
+		b := "0"
+		if v {
+			b = "1"
+		}
+		return xlsx.NewStreamCell(b, xlsx.StreamStyleDefaultString, xlsx.CellTypeBool)
+	case int64:
+		return xlsx.NewStreamCell(strconv.FormatInt(v, 10), xlsx.StreamStyleDefaultString, xlsx.CellTypeNumeric)
+	case float64:
+		return xlsx.NewStreamCell(strconv.FormatFloat(v, 'f', -1, 64), xlsx.StreamStyleDefaultString, xlsx.CellTypeNumeric)
+	case time.Time:
+		t := xlsx.TimeToExcelTime(time.Unix(v.Unix(), 0).UTC(), false)
+		return xlsx.NewStreamCell(strconv.FormatFloat(t, 'f', -1, 64), dateTimeStyle, xlsx.CellTypeNumeric)
+	case string:
+		return xlsx.NewStringStreamCell(v)
+	}
+	return xlsx.NewStringStreamCell("")
+}
@@ -0,0 +1,75 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/tealeg/xlsx"
+)
+
+// func (x *Xlsx) WriteRow(s *Sheet, row Row) error
+func TestXlsxWriteRow(t *testing.T) {
+	s := &Sheet{Name: test1, Title: "Typed", Columns: []*Column{
+		{FriendlyName: "Name"},
+		{FriendlyName: "Enabled"},
+		{FriendlyName: "Count"},
+		{FriendlyName: "Ratio"},
+		{FriendlyName: "Created"},
+		{FriendlyName: "Missing"},
+	}}
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	x := NewXlsx()
+	defer x.Close()
+	err := x.AddSheet(s)
+	if err != nil {
+		t.Fatalf("failed to call AddSheet: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		err = x.WriteRow(s, Row{
+			{"Name", "web"},
+			{"Enabled", true},
+			{"Count", int64(i)},
+			{"Ratio", 0.5},
+			{"Created", created},
+			{"Missing", nil},
+		})
+		if err != nil {
+			t.Fatalf("failed to call WriteRow: %v", err)
+		}
+	}
+	buf := bytes.Buffer{}
+	err = x.Render(&buf)
+	if err != nil {
+		t.Fatalf("failed to call Render: %v", err)
+	}
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	if err != nil {
+		t.Fatalf("failed to open workbook: %v", err)
+	}
+	sheet, ok := f.Sheet["Typed"]
+	if !ok {
+		t.Fatalf("worksheet Typed is missing, got: %v", f.Sheets)
+	}
+	if len(sheet.Rows) != 4 {
+		t.Fatalf("Rows invalid, expected: 4, got: %d", len(sheet.Rows))
+	}
+	if v := sheet.Cell(3, 0).Value; v != "web" {
+		t.Fatalf("Name invalid, expected: web, got: %s", v)
+	}
+	if !sheet.Cell(3, 1).Bool() {
+		t.Fatal("Enabled invalid, expected: true, got: false")
+	}
+	if v, err := sheet.Cell(3, 2).Int64(); err != nil || v != 2 {
+		t.Fatalf("Count invalid, expected: 2, got: %d (%v)", v, err)
+	}
+	if v, err := sheet.Cell(3, 3).Float(); err != nil || v != 0.5 {
+		t.Fatalf("Ratio invalid, expected: 0.5, got: %f (%v)", v, err)
+	}
+	if v, err := sheet.Cell(3, 4).GetTime(false); err != nil || !v.Round(time.Second).Equal(created) {
+		t.Fatalf("Created invalid, expected: %v, got: %v (%v)", created, v, err)
+	}
+	if v := sheet.Cell(3, 5).Value; v != "" {
+		t.Fatalf("Missing invalid, expected: empty, got: %s", v)
+	}
+}
@@ -34,10 +34,12 @@ resource "aws_iam_policy" "iam_policy" {
         "cloudformation:DescribeStacks",
         "cloudwatch:DescribeAlarms",
         "config:DescribeConfigRules",
+        "dynamodb:Scan",
         "ec2:DescribeAddresses",
         "ec2:DescribeImages",
         "ec2:DescribeInstances",
         "ec2:DescribeKeyPairs",
+        "ec2:DescribeRegions",
         "ec2:DescribeSecurityGroups",
         "ec2:DescribeSnapshots",
         "ec2:DescribeSubnets",
@@ -54,8 +56,18 @@ resource "aws_iam_policy" "iam_policy" {
         "kms:ListKeys",
         "kms:DescribeKey",
         "kms:ListAliases",
+        "organizations:DescribeAccount",
         "organizations:ListAccounts",
         "organizations:ListAccountsForParent",
+        "organizations:ListOrganizationalUnitsForParent",
+        "organizations:ListParents",
+        "organizations:ListRoots",
+        "organizations:DescribeOrganizationalUnit",
+        "organizations:ListPolicies",
+        "organizations:ListTargetsForPolicy",
+        "organizations:ListDelegatedAdministrators",
+        "organizations:ListDelegatedServicesForAccount",
+        "organizations:ListTagsForResource",
         "rds:DescribeDBInstances",
         "rds:DescribeDBSnapshots",
         "s3:ListBucket",
@@ -66,6 +78,7 @@ resource "aws_iam_policy" "iam_policy" {
         "sns:ListSubscriptions",
         "sns:ListTopics",
         "ssm:DescribeParameters",
+        "ssm:GetParameter",
         "logs:CreateLogGroup",
         "logs:CreateLogStream",
         "logs:PutLogEvents"
@@ -87,7 +100,9 @@ resource "aws_iam_policy" "iam_policy" {
     {
       "Action": [
         "s3:GetObject",
-        "s3:PutObject"
+        "s3:PutObject",
+        "s3:AbortMultipartUpload",
+        "s3:ListMultipartUploadParts"
       ],
       "Effect": "Allow",
       "Resource": "${aws_s3_bucket.bucket.arn}/*"
@@ -110,3 +125,20 @@ resource "aws_iam_role_policy_attachment" "iam_role_policy_attachment" {
   policy_arn = aws_iam_policy.iam_policy.arn
 }
 
+
+resource "aws_iam_role_policy" "role_chain" {
+  count = var.role_chain == "" ? 0 : 1
+  name  = "${local.app_name}-role-chain"
+  role  = aws_iam_role.iam_role.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action   = "sts:AssumeRole"
+        Effect   = "Allow"
+        Resource = split(",", var.role_chain)[0]
+      }
+    ]
+  })
+}
@@ -13,10 +13,25 @@ resource "aws_lambda_function" "lambda_function" {
 
   environment {
     variables = {
-      accounts_info     = var.accounts_info
-      kms_key_id        = aws_kms_key.kms_key.key_id
-      master_role_name  = var.master_role_name
-      master_account_id = var.master_account_id
+      accounts_info                  = var.accounts_info
+      account_roles                  = var.account_roles
+      role_chain                     = var.role_chain
+      kms_key_id                     = aws_kms_key.kms_key.key_id
+      master_role_name               = var.master_role_name
+      master_account_id              = var.master_account_id
+      max_workers                    = var.max_workers
+      rate_limits                    = var.rate_limits
+      retry_attempts                 = var.retry_attempts
+      partial_results                = var.partial_results
+      deadline_margin                = var.deadline_margin
+      output_formats                 = var.output_formats
+      drift_report                   = var.drift_report
+      region_include                 = var.region_include
+      region_exclude                 = var.region_exclude
+      organizational_units_recursive = var.organizational_units_recursive
+      organizational_units_exclude   = var.organizational_units_exclude
+      account_include                = var.account_include
+      account_exclude                = var.account_exclude
       // organizational_units = "${organizational_units}"
       regions          = var.regions
       s3_bucket        = aws_s3_bucket.bucket.bucket
@@ -10,6 +10,18 @@ variable "tenant_role_name" {
   default     = "OrganizationAccountAccessRole"
 }
 
+variable "account_roles" {
+  type        = string
+  description = "(optional) JSON object of account IDs to the RoleName, ExternalId, SessionName and DurationSeconds used to assume the role of that account"
+  default     = ""
+}
+
+variable "role_chain" {
+  type        = string
+  description = "(optional) comma delimited list of role ARNs assumed in order before the master and tenant roles"
+  default     = ""
+}
+
 variable "master_role_name" {
   type        = string
   description = "(optional) Role assumed by lambda function to query organizations in Master Payer account"
@@ -18,7 +30,7 @@ variable "master_role_name" {
 
 variable "regions" {
   type        = string
-  description = "(optional) Comma delimited list of AWS regions to inventory"
+  description = "(optional) Comma delimited list of AWS regions to inventory, or all/enabled to discover the regions of each account"
   default     = "us-east-1,us-east-2,us-west-1,us-west-2"
 }
 
@@ -46,6 +58,30 @@ variable "organizational_units" {
   default     = ""
 }
 
+variable "organizational_units_recursive" {
+  type        = bool
+  description = "(optional) If true, also query accounts in the child organizational units of organizational_units"
+  default     = false
+}
+
+variable "organizational_units_exclude" {
+  type        = string
+  description = "(optional) comma delimited list of patterns matched against the ID, name and path of child organizational units, matching units and their children are skipped"
+  default     = ""
+}
+
+variable "account_include" {
+  type        = string
+  description = "(optional) comma delimited list of id:, name:, status: or tag: selectors of the accounts to inventory"
+  default     = ""
+}
+
+variable "account_exclude" {
+  type        = string
+  description = "(optional) comma delimited list of id:, name:, status: or tag: selectors of the accounts not to inventory"
+  default     = ""
+}
+
 variable "source_file" {
   type        = string
   description = "(optional) full or relative path to zipped binary of lambda handler"
@@ -74,4 +110,58 @@ variable "lambda_memory" {
   type        = number
   description = "(optional) The number of megabytes of RAM to use for the inventory lambda"
   default     = 2048
-}
\ No newline at end of file
+}
+
+variable "max_workers" {
+  type        = number
+  description = "(optional) The maximum number of account, region and service queries to run concurrently"
+  default     = 10
+}
+
+variable "rate_limits" {
+  type        = string
+  description = "(optional) comma delimited list of service=rate pairs limiting the requests per second made to a service in each account and region (e.g. ec2=20,iam=5)"
+  default     = ""
+}
+
+variable "retry_attempts" {
+  type        = number
+  description = "(optional) The number of attempts made for a throttled request before it is reported as an error"
+  default     = 5
+}
+
+variable "deadline_margin" {
+  type        = string
+  description = "(optional) How long before the Lambda timeout to stop querying and save a truncated report"
+  default     = "1m"
+}
+
+variable "output_formats" {
+  type        = string
+  description = "(optional) Comma delimited list of report formats to save, any of xlsx, ndjson and csv"
+  default     = "xlsx"
+}
+
+variable "partial_results" {
+  type        = bool
+  description = "(optional) Record unexpected errors and save an incomplete report instead of failing"
+  default     = false
+}
+
+variable "drift_report" {
+  type        = bool
+  description = "(optional) Save a change report comparing each run against the previous one, also saves the ndjson format"
+  default     = false
+}
+
+variable "region_include" {
+  type        = string
+  description = "(optional) Comma delimited list of region patterns to inventory, e.g. us-*, all other regions are skipped"
+  default     = ""
+}
+
+variable "region_exclude" {
+  type        = string
+  description = "(optional) Comma delimited list of region patterns to skip, e.g. ap-*"
+  default     = ""
+}